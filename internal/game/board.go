@@ -8,11 +8,13 @@ import (
 type Board struct {
 	Width  int
 	Height int
+	Walls  map[Position]bool // internal walls, empty on an open board
+	Rooms  []Room            // node rooms, empty on an open board
 }
 
 // NewBoard creates a board with the given dimensions.
 func NewBoard(width, height int) *Board {
-	return &Board{Width: width, Height: height}
+	return &Board{Width: width, Height: height, Walls: make(map[Position]bool)}
 }
 
 // IsOutOfBounds returns true if the position is outside the board.
@@ -20,21 +22,56 @@ func (b *Board) IsOutOfBounds(p Position) bool {
 	return p.X < 0 || p.X >= b.Width || p.Y < 0 || p.Y >= b.Height
 }
 
-// RandomPosition returns a random position within the board that does not
-// overlap with any of the excluded positions.
-func (b *Board) RandomPosition(excluded []Position) Position {
+// Wrap moves a position that left the board back in from the opposite edge.
+func (b *Board) Wrap(p Position) Position {
+	return Position{
+		X: (p.X%b.Width + b.Width) % b.Width,
+		Y: (p.Y%b.Height + b.Height) % b.Height,
+	}
+}
+
+// Bounds returns the whole board as a rectangle.
+func (b *Board) Bounds() Rect {
+	return Rect{Max: Position{X: b.Width, Y: b.Height}}
+}
+
+// FreeCells returns every position inside area that is on the board, not
+// blocked by a wall or hazard, and not in excluded.
+func (b *Board) FreeCells(area Rect, excluded []Position) []Position {
 	excludeSet := make(map[Position]bool, len(excluded))
 	for _, p := range excluded {
 		excludeSet[p] = true
 	}
 
-	for {
-		p := Position{
-			X: rand.Intn(b.Width),
-			Y: rand.Intn(b.Height),
-		}
-		if !excludeSet[p] {
-			return p
+	var free []Position
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			p := Position{X: x, Y: y}
+			if !excludeSet[p] && !b.IsBlocked(p) {
+				free = append(free, p)
+			}
 		}
 	}
+	return free
+}
+
+// OpenCells returns how many cells the snake could ever occupy.
+func (b *Board) OpenCells() int {
+	return len(b.FreeCells(b.Bounds(), nil))
+}
+
+// RandomPosition returns a random position within the board that does not
+// overlap with any of the excluded positions, walls or hazards.
+// The second result is false when no such position exists.
+func (b *Board) RandomPosition(excluded []Position) (Position, bool) {
+	return b.RandomPositionIn(b.Bounds(), excluded)
+}
+
+// RandomPositionIn is like RandomPosition but restricted to the given area.
+func (b *Board) RandomPositionIn(area Rect, excluded []Position) (Position, bool) {
+	free := b.FreeCells(area, excluded)
+	if len(free) == 0 {
+		return Position{}, false
+	}
+	return free[rand.Intn(len(free))], true
 }
@@ -0,0 +1,81 @@
+package game
+
+import "fmt"
+
+// DecoyRule decides what happens when the snake touches a protected pod.
+type DecoyRule int
+
+const (
+	DecoysOff        DecoyRule = iota // no decoys spawn
+	DecoysEndGame                     // touching a decoy ends the game
+	DecoysCostPoints                  // touching a decoy costs DecoyPenalty points
+)
+
+// DecoyPenalty is how many points a decoy costs under DecoysCostPoints.
+const DecoyPenalty = 5
+
+// String returns the name used for the rule in menus and flags.
+func (r DecoyRule) String() string {
+	switch r {
+	case DecoysEndGame:
+		return "end"
+	case DecoysCostPoints:
+		return "points"
+	default:
+		return "off"
+	}
+}
+
+// ParseDecoyRule converts a flag value into a DecoyRule.
+func ParseDecoyRule(s string) (DecoyRule, error) {
+	switch s {
+	case "", "off":
+		return DecoysOff, nil
+	case "end":
+		return DecoysEndGame, nil
+	case "points":
+		return DecoysCostPoints, nil
+	}
+	return DecoysOff, fmt.Errorf("unknown decoy rule %q (want off, end or points)", s)
+}
+
+// EdibleCount returns how many pods on the board can be eaten.
+func (g *Game) EdibleCount() int {
+	n := 0
+	for _, p := range g.Pods {
+		if !p.Protected && p.Phase.Edible() {
+			n++
+		}
+	}
+	return n
+}
+
+// DecoyCount returns how many protected pods are on the board.
+func (g *Game) DecoyCount() int {
+	n := 0
+	for _, p := range g.Pods {
+		if p.Protected {
+			n++
+		}
+	}
+	return n
+}
+
+// hitDecoy applies the decoy rule after a player's head touched a protected
+// pod. Zen mode always costs points, since nothing ends a zen game.
+// Returns true if the decoy stays on the board.
+func (g *Game) hitDecoy(p *Player, pod Pod) bool {
+	g.DecoysHit = append(g.DecoysHit, pod)
+	switch {
+	case g.Decoys == DecoysCostPoints, g.Mode == ModeZen:
+		// Only what the player had is lost, so the total stays the sum
+		// of the players' scores.
+		lost := min(p.Score, DecoyPenalty)
+		p.Score -= lost
+		g.Score -= lost
+		return false
+	default:
+		p.Alive = false
+		return true
+	}
+}
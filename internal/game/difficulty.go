@@ -0,0 +1,112 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// Difficulty controls how fast the game speeds up and how crowded the
+// board gets as the player scores.
+type Difficulty struct {
+	Name          string
+	BaseTick      time.Duration // tick rate at score zero
+	MinTick       time.Duration // fastest the game will ever run
+	TickStep      time.Duration // tick shortened per point scored
+	BaseMaxPods   int           // pods on board at level 1
+	MaxPodsCap    int           // most pods on board at any level
+	KillsPerLevel int
+}
+
+// Difficulty presets selectable from the menu and --difficulty.
+var (
+	Easy = Difficulty{
+		Name:          "easy",
+		BaseTick:      200 * time.Millisecond,
+		MinTick:       100 * time.Millisecond,
+		TickStep:      1 * time.Millisecond,
+		BaseMaxPods:   4,
+		MaxPodsCap:    6,
+		KillsPerLevel: 15,
+	}
+	Normal = Difficulty{
+		Name:          "normal",
+		BaseTick:      150 * time.Millisecond,
+		MinTick:       70 * time.Millisecond,
+		TickStep:      2 * time.Millisecond,
+		BaseMaxPods:   3,
+		MaxPodsCap:    6,
+		KillsPerLevel: 10,
+	}
+	Hard = Difficulty{
+		Name:          "hard",
+		BaseTick:      110 * time.Millisecond,
+		MinTick:       50 * time.Millisecond,
+		TickStep:      3 * time.Millisecond,
+		BaseMaxPods:   2,
+		MaxPodsCap:    5,
+		KillsPerLevel: 5,
+	}
+)
+
+// Tuned returns d starting at the given tick rate and keeping the given
+// number of pods on the board. Zero keeps d's own. The game still speeds
+// up from the tick rate as it scores.
+func (d Difficulty) Tuned(tick time.Duration, maxPods int) Difficulty {
+	if tick > 0 {
+		d.BaseTick = tick
+		d.MinTick = min(d.MinTick, tick)
+	}
+	if maxPods > 0 {
+		d.BaseMaxPods, d.MaxPodsCap = maxPods, maxPods
+	}
+	return d
+}
+
+// Difficulties returns the presets in menu order.
+func Difficulties() []Difficulty {
+	return []Difficulty{Easy, Normal, Hard}
+}
+
+// ParseDifficulty looks up a preset by name.
+func ParseDifficulty(s string) (Difficulty, error) {
+	if s == "" {
+		return Normal, nil
+	}
+	for _, d := range Difficulties() {
+		if d.Name == s {
+			return d, nil
+		}
+	}
+	return Normal, fmt.Errorf("unknown difficulty %q (want easy, normal or hard)", s)
+}
+
+// SetDifficulty applies a preset and resets the level progression.
+func (g *Game) SetDifficulty(d Difficulty) {
+	g.Difficulty = d
+	g.updateLevel()
+}
+
+// TickRate returns how long the current frame should last. The game speeds
+// up by TickStep for every point scored, down to MinTick.
+func (g *Game) TickRate() time.Duration {
+	d := g.Difficulty
+	rate := d.BaseTick - time.Duration(g.Score)*d.TickStep
+	if rate < d.MinTick {
+		rate = d.MinTick
+	}
+	return rate
+}
+
+// updateLevel recomputes the level from the kill count and raises MaxPods
+// by one per level, up to the preset's cap.
+func (g *Game) updateLevel() {
+	d := g.Difficulty
+	g.Level = 1
+	if d.KillsPerLevel > 0 {
+		g.Level += g.KillCount / d.KillsPerLevel
+	}
+	g.MaxPods = d.BaseMaxPods + g.Level - 1
+	if g.MaxPods > d.MaxPodsCap {
+		g.MaxPods = d.MaxPodsCap
+	}
+}
@@ -0,0 +1,37 @@
+package game
+
+// FadeTicks is how long an evaporating pod stays visible.
+const FadeTicks = 6
+
+// Fade marks a cell where a pod vanished without being eaten. It is purely
+// visual: the snake and other pods can move through it.
+type Fade struct {
+	Pos  Position
+	Left int // ticks until the fade disappears
+}
+
+// RemovePod takes a pod off the board without awarding points, e.g. because
+// it was deleted or stopped running in the cluster. The pod leaves a short
+// fade behind. Returns the removed pod and true if it was on the board.
+func (g *Game) RemovePod(name, namespace string) (Pod, bool) {
+	for i, pod := range g.Pods {
+		if pod.Name == name && pod.Namespace == namespace {
+			g.Pods = append(g.Pods[:i], g.Pods[i+1:]...)
+			g.Fades = append(g.Fades, Fade{Pos: pod.Pos, Left: FadeTicks})
+			return pod, true
+		}
+	}
+	return Pod{}, false
+}
+
+// tickFades ages every fade by one tick and drops finished ones.
+func (g *Game) tickFades() {
+	remaining := g.Fades[:0]
+	for _, f := range g.Fades {
+		f.Left--
+		if f.Left > 0 {
+			remaining = append(remaining, f)
+		}
+	}
+	g.Fades = remaining
+}
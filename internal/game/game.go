@@ -1,5 +1,7 @@
 package game
 
+import "time"
+
 // State represents the current phase of the game.
 type State int
 
@@ -7,94 +9,259 @@ const (
 	StateRunning State = iota
 	StatePaused
 	StateOver
+	StateWon // the snake fills every open cell
 )
 
-// Game ties together the snake, board, and pod targets.
+// Game ties together the snakes, board, and pod targets.
 type Game struct {
-	Snake      *Snake
+	Players    []*Player // Players[0] is player one
 	Board      *Board
 	Pods       []Pod
 	State      State
-	Score      int
-	KillCount  int
+	Score      int // total across players
+	KillCount  int // total across players
 	MaxPods    int // maximum pods visible on board at once
+	Layout     Layout
+	Difficulty Difficulty
+	Level      int
+	Items      []Item // power-ups on the board
+	MaxItems   int    // maximum power-ups visible on board at once
+	Collected  []Item // power-ups eaten during the last tick
+	Decoys     DecoyRule
+	MaxDecoys  int   // maximum protected pods visible on board at once
+	DecoysHit  []Pod // protected pods touched during the last tick
+	Movement   PodMovement
+	MoveEvery  int    // ticks between steps for a pod with no speed bonus
+	Ticks      int    // frames played so far
+	Fades      []Fade // pods that vanished on their own, fading out
+	Mode       Mode
+	Elapsed    time.Duration // game time played, the sum of every tick's rate
+	Hardened   []Pod         // pods turned into walls during the last tick
 }
 
-// New creates a new game with default settings.
+// New creates a new single-player game with default settings.
 // The board dimensions are passed in so the UI can control sizing.
 func New(boardWidth, boardHeight int) *Game {
 	board := NewBoard(boardWidth, boardHeight)
 	start := Position{X: boardWidth / 4, Y: boardHeight / 2}
 	snake := NewSnake(start)
 
-	return &Game{
-		Snake:   snake,
-		Board:   board,
-		Pods:    []Pod{},
-		State:   StateRunning,
-		MaxPods: 3,
+	g := &Game{
+		Players:   []*Player{newPlayer("player", snake)},
+		Board:     board,
+		Pods:      []Pod{},
+		State:     StateRunning,
+		MaxItems:  1,
+		MaxDecoys: 2,
+		MoveEvery: defaultMoveEvery,
 	}
+	g.SetDifficulty(Normal)
+	return g
 }
 
-// Tick advances the game by one frame. Returns a list of pod names that
-// were eaten (and should be killed in k8s).
+// Tick advances the game by one frame. Returns the pods that were eaten
+// (and should be killed in k8s), each credited to the player that ate it.
+// The game is over once every human has crashed, or every bot in a game
+// without humans.
 func (g *Game) Tick() []Pod {
 	if g.State != StateRunning {
 		return nil
 	}
 
-	g.Snake.Move()
-	head := g.Snake.Head()
+	g.Ticks++
+	g.Elapsed += g.TickRate()
+	g.tickFades()
+	g.Collected = nil
+	g.DecoysHit = nil
+	g.Hardened = nil
 
-	// TODO: wall collision -- game over
-	if g.Board.IsOutOfBounds(head) {
-		g.State = StateOver
-		return nil
+	g.steerBots()
+	alive := g.Alive()
+	for _, p := range alive {
+		p.tickEffects()
+		p.Snake.Move()
+		if p.Active(PowerPhase) || g.Mode == ModeZen {
+			p.Snake.Body[0] = g.Board.Wrap(p.Snake.Head())
+		}
+	}
+
+	// Check crashes only after every snake moved, so head-to-head
+	// collisions take out both players.
+	var crashed []*Player
+	for _, p := range alive {
+		if g.crashed(p) {
+			crashed = append(crashed, p)
+		}
+	}
+	for _, p := range crashed {
+		p.Alive = false
 	}
 
-	// TODO: self collision -- game over
-	if g.Snake.CollidesWithSelf() {
+	var eaten []Pod
+	for i, p := range g.Players {
+		if p.Alive {
+			eaten = append(eaten, g.feed(i)...)
+			p.Longest = max(p.Longest, p.Snake.Length())
+		}
+	}
+	if len(eaten) > 0 {
+		g.updateLevel()
+	}
+
+	if g.over() {
 		g.State = StateOver
-		return nil
+		return eaten
 	}
+	g.movePods()
+	if g.Mode == ModeSurvival {
+		g.hardenPods()
+	}
+	if g.Mode == ModeTimeAttack && g.TimeLeft() == 0 {
+		g.State = StateOver
+		return eaten
+	}
+
+	length := 0
+	for _, p := range g.Alive() {
+		length += p.Snake.Length()
+	}
+	if length >= g.Board.OpenCells() {
+		g.State = StateWon
+	}
+
+	return eaten
+}
 
-	// Check if we ate a pod
+// feed lets the i-th player eat whatever is under its head: power-ups, pods
+// and decoys. Returns the pods eaten.
+func (g *Game) feed(i int) []Pod {
+	p := g.Players[i]
+	head := p.Snake.Head()
+	g.Collected = append(g.Collected, g.collectItems(p, head)...)
+
+	multiplier := 1
+	if p.Active(PowerDoublePoints) {
+		multiplier = 2
+	}
 	var eaten []Pod
 	remaining := make([]Pod, 0, len(g.Pods))
 	for _, pod := range g.Pods {
-		if pod.Pos == head {
+		if pod.Pos != head {
+			remaining = append(remaining, pod)
+			continue
+		}
+		pod.EatenBy = i
+		if pod.Protected {
+			// Decoys are hazards, not food: never returned for deletion.
+			if g.hitDecoy(p, pod) {
+				remaining = append(remaining, pod)
+			}
+		} else if pod.Phase.Edible() {
+			p.Snake.Grow()
+			points := pod.Phase.Points() * multiplier
+			pod.Points = points
 			eaten = append(eaten, pod)
-			g.Snake.Grow()
-			g.Score++
+			p.Score += points
+			p.KillCount++
+			g.Score += points
 			g.KillCount++
 		} else {
 			remaining = append(remaining, pod)
 		}
 	}
 	g.Pods = remaining
-
 	return eaten
 }
 
+// Spare takes back the points and kill awarded for an eaten pod that was
+// left alive in the cluster, so the score only counts pods really killed.
+func (g *Game) Spare(pod Pod) {
+	p := g.Players[pod.EatenBy]
+	p.Score -= pod.Points
+	p.KillCount--
+	g.Score -= pod.Points
+	g.KillCount--
+	g.updateLevel()
+}
+
 // PlacePod adds a pod to the board at a random free position.
 // Returns true if the pod was placed, false if the board is full.
 func (g *Game) PlacePod(name, namespace string) bool {
-	if len(g.Pods) >= g.MaxPods {
+	return g.AddPod(Pod{Name: name, Namespace: namespace})
+}
+
+// AddPod places the given pod at a random free position, ignoring any
+// position it already carries. In the node and namespace layouts the pod
+// spawns inside its room when that room is safe to enter and has space.
+// Protected pods count against MaxDecoys instead of MaxPods.
+// Returns true if the pod was placed, false if the board is full.
+func (g *Game) AddPod(pod Pod) bool {
+	if pod.Protected {
+		if g.Decoys == DecoysOff || g.DecoyCount() >= g.MaxDecoys {
+			return false
+		}
+	} else if g.EdibleCount() >= g.MaxPods {
+		return false
+	}
+
+	occupied := g.occupied()
+
+	var pos Position
+	placed := false
+	if room, ok := g.roomFor(pod); ok && !room.Hazard {
+		pos, placed = g.Board.RandomPositionIn(room.Bounds, occupied)
+	}
+	if !placed {
+		// No room for this pod (or its room is full): spawn anywhere.
+		pos, placed = g.Board.RandomPosition(occupied)
+	}
+	if !placed {
 		return false
 	}
+	pod.Pos = pos
+	pod.Born = g.Ticks
+	g.Pods = append(g.Pods, pod)
+	return true
+}
+
+// PodAt returns the pod at pos, if any.
+func (g *Game) PodAt(pos Position) (Pod, bool) {
+	for _, p := range g.Pods {
+		if p.Pos == pos {
+			return p, true
+		}
+	}
+	return Pod{}, false
+}
+
+// NearestPod returns the pod closest to pos, decoys included. Ties go to
+// the pod placed first.
+func (g *Game) NearestPod(pos Position) (Pod, bool) {
+	best := -1
+	for i, p := range g.Pods {
+		if best < 0 || distance(pos, p.Pos) < distance(pos, g.Pods[best].Pos) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return Pod{}, false
+	}
+	return g.Pods[best], true
+}
 
-	occupied := g.Snake.Body
+// occupied returns every position taken by the snakes, pods and items.
+func (g *Game) occupied() []Position {
+	var occupied []Position
+	for _, p := range g.Alive() {
+		occupied = append(occupied, p.Snake.Body...)
+	}
 	for _, p := range g.Pods {
 		occupied = append(occupied, p.Pos)
 	}
-
-	pos := g.Board.RandomPosition(occupied)
-	g.Pods = append(g.Pods, Pod{
-		Pos:       pos,
-		Name:      name,
-		Namespace: namespace,
-	})
-	return true
+	for _, it := range g.Items {
+		occupied = append(occupied, it.Pos)
+	}
+	return occupied
 }
 
 // TogglePause pauses or resumes the game.
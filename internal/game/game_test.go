@@ -1,6 +1,9 @@
 package game
 
-import "testing"
+import (
+	"testing"
+	"testing/quick"
+)
 
 func TestNewSnake(t *testing.T) {
 	s := NewSnake(Position{X: 5, Y: 5})
@@ -35,8 +38,76 @@ func TestSnakeGrow(t *testing.T) {
 func TestSnakeReversal(t *testing.T) {
 	s := NewSnake(Position{X: 5, Y: 5})
 	s.SetDirection(Left)
-	if s.Direction != Right {
-		t.Fatal("snake should not reverse 180 degrees")
+	s.Move()
+	if s.Head() != (Position{X: 6, Y: 5}) {
+		t.Fatalf("snake should not reverse 180 degrees, head at %v", s.Head())
+	}
+
+	// Up is queued, so Down is checked against Up rather than the current
+	// heading and dropped; Left after it is a real turn.
+	s.SetDirection(Up)
+	s.SetDirection(Down)
+	s.SetDirection(Left)
+	s.Move()
+	if s.Head() != (Position{X: 6, Y: 4}) {
+		t.Fatalf("expected head at (6,4) after the queued turn up, got %v", s.Head())
+	}
+	s.Move()
+	if s.Head() != (Position{X: 5, Y: 4}) {
+		t.Fatalf("expected head at (5,4) after turning left, got %v", s.Head())
+	}
+}
+
+func TestSnakeQuickDoubleTurn(t *testing.T) {
+	s := NewSnake(Position{X: 5, Y: 5})
+	// Up then Left pressed between two ticks: both turns must apply.
+	s.SetDirection(Up)
+	s.SetDirection(Left)
+
+	s.Move()
+	if s.Head() != (Position{X: 5, Y: 4}) {
+		t.Fatalf("expected head at (5,4) after first turn, got %v", s.Head())
+	}
+	s.Move()
+	if s.Head() != (Position{X: 4, Y: 4}) {
+		t.Fatalf("expected head at (4,4) after second turn, got %v", s.Head())
+	}
+	if s.CollidesWithSelf() {
+		t.Fatal("quick double turn should not collide with self")
+	}
+}
+
+func TestSnakeQueuedReversal(t *testing.T) {
+	s := NewSnake(Position{X: 5, Y: 5})
+	// Right -> Up -> Down before a tick: Down reverses the queued Up.
+	s.SetDirection(Up)
+	s.SetDirection(Down)
+
+	s.Move()
+	s.Move()
+	if s.Direction != Up {
+		t.Fatalf("expected reversal of queued turn to be dropped, heading %v", s.Direction)
+	}
+	if s.CollidesWithSelf() {
+		t.Fatal("snake should not turn into itself")
+	}
+}
+
+func TestGameTickAppliesOneTurnPerTick(t *testing.T) {
+	g := New(20, 20)
+	g.Players[0].Snake.SetDirection(Down)
+	g.Players[0].Snake.SetDirection(Left)
+
+	g.Tick()
+	if g.Players[0].Snake.Direction != Down {
+		t.Fatalf("expected only the first queued turn after one tick, heading %v", g.Players[0].Snake.Direction)
+	}
+	g.Tick()
+	if g.Players[0].Snake.Direction != Left {
+		t.Fatalf("expected second queued turn after two ticks, heading %v", g.Players[0].Snake.Direction)
+	}
+	if g.State != StateRunning {
+		t.Fatal("expected game still running after quick turns")
 	}
 }
 
@@ -80,7 +151,7 @@ func TestGameTick(t *testing.T) {
 func TestGameOver(t *testing.T) {
 	// Create a tiny board so the snake hits a wall quickly
 	g := New(4, 4)
-	g.Snake = NewSnake(Position{X: 2, Y: 2})
+	g.Players[0].Snake = NewSnake(Position{X: 2, Y: 2})
 
 	for i := 0; i < 10; i++ {
 		g.Tick()
@@ -89,3 +160,734 @@ func TestGameOver(t *testing.T) {
 		t.Fatal("expected game over after snake runs off small board")
 	}
 }
+
+func TestSplitRooms(t *testing.T) {
+	b := NewBoard(40, 20)
+	b.SplitRooms([]Node{{Name: "node-a"}, {Name: "node-b"}, {Name: "node-c", Hazard: true}})
+
+	if len(b.Rooms) != 3 {
+		t.Fatalf("expected 3 rooms, got %d", len(b.Rooms))
+	}
+	if b.Rooms[2].Name != "node-c" || !b.Rooms[2].Hazard {
+		t.Fatalf("expected hazard room sorted last, got %+v", b.Rooms[2])
+	}
+	for _, room := range b.Rooms {
+		for p := range b.Walls {
+			if room.Bounds.Contains(p) {
+				t.Fatalf("wall %v inside room %s", p, room.Name)
+			}
+		}
+	}
+	if !b.IsBlocked(b.Rooms[2].Bounds.Min) {
+		t.Fatal("hazard room should be blocked")
+	}
+	if b.IsBlocked(b.Rooms[0].Bounds.Min) {
+		t.Fatal("safe room should not be blocked")
+	}
+}
+
+func TestPodSpawnsInNodeRoom(t *testing.T) {
+	g := New(40, 20)
+	g.SetTopology([]Node{{Name: "node-a"}, {Name: "node-b"}})
+
+	room, ok := g.Board.Room("node-b")
+	if !ok {
+		t.Fatal("expected a room for node-b")
+	}
+	for i := 0; i < 3; i++ {
+		if !g.AddPod(Pod{Name: "p", Namespace: "default", Node: "node-b"}) {
+			t.Fatal("expected pod to be placed")
+		}
+	}
+	for _, pod := range g.Pods {
+		if !room.Bounds.Contains(pod.Pos) {
+			t.Fatalf("pod at %v outside node-b room %+v", pod.Pos, room.Bounds)
+		}
+	}
+	for _, seg := range g.Players[0].Snake.Body {
+		if g.Board.IsBlocked(seg) {
+			t.Fatalf("snake starts on blocked cell %v", seg)
+		}
+	}
+}
+
+func TestPodSpawnsInNamespaceZone(t *testing.T) {
+	g := New(40, 20)
+	g.SetZones([]string{"default", "snakefood"})
+
+	zone, ok := g.Board.Room("snakefood")
+	if !ok {
+		t.Fatal("expected a zone for snakefood")
+	}
+	g.AddPod(Pod{Name: "p", Namespace: "snakefood", Node: "node-a"})
+	if !zone.Bounds.Contains(g.Pods[0].Pos) {
+		t.Fatalf("pod at %v outside snakefood zone %+v", g.Pods[0].Pos, zone.Bounds)
+	}
+}
+
+func TestDifficultyProgression(t *testing.T) {
+	g := New(20, 20)
+	g.SetDifficulty(Hard)
+
+	if g.TickRate() != Hard.BaseTick {
+		t.Fatalf("expected base tick %v, got %v", Hard.BaseTick, g.TickRate())
+	}
+	if g.Level != 1 || g.MaxPods != Hard.BaseMaxPods {
+		t.Fatalf("expected level 1 with %d pods, got level %d with %d", Hard.BaseMaxPods, g.Level, g.MaxPods)
+	}
+
+	g.Score = 1000
+	g.KillCount = Hard.KillsPerLevel * 2
+	g.updateLevel()
+
+	if g.TickRate() != Hard.MinTick {
+		t.Fatalf("expected tick clamped to %v, got %v", Hard.MinTick, g.TickRate())
+	}
+	if g.Level != 3 {
+		t.Fatalf("expected level 3, got %d", g.Level)
+	}
+	if g.MaxPods != Hard.BaseMaxPods+2 {
+		t.Fatalf("expected %d max pods, got %d", Hard.BaseMaxPods+2, g.MaxPods)
+	}
+}
+
+// tinyBoard builds a board of up to 4x4 cells from quick-generated values,
+// excluding every cell whose bit is set in mask.
+func tinyBoard(w, h uint8, mask uint16) (*Board, []Position) {
+	b := NewBoard(int(w%4)+1, int(h%4)+1)
+	var excluded []Position
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			if mask&(1<<uint(y*4+x)) != 0 {
+				excluded = append(excluded, Position{X: x, Y: y})
+			}
+		}
+	}
+	return b, excluded
+}
+
+func TestRandomPositionProperties(t *testing.T) {
+	property := func(w, h uint8, mask uint16) bool {
+		b, excluded := tinyBoard(w, h, mask)
+		free := b.Width*b.Height - len(excluded)
+
+		p, ok := b.RandomPosition(excluded)
+		if ok != (free > 0) {
+			return false
+		}
+		if !ok {
+			return true
+		}
+		if b.IsOutOfBounds(p) {
+			return false
+		}
+		for _, e := range excluded {
+			if e == p {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 2000}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRandomPositionFullBoard(t *testing.T) {
+	b := NewBoard(2, 2)
+	all := []Position{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}
+	if _, ok := b.RandomPosition(all); ok {
+		t.Fatal("expected no position on a full board")
+	}
+	if p, ok := b.RandomPosition(all[:3]); !ok || p != (Position{X: 1, Y: 1}) {
+		t.Fatalf("expected the only free cell (1,1), got %v ok=%v", p, ok)
+	}
+}
+
+func TestPlacePodOnFullBoard(t *testing.T) {
+	g := New(3, 1)
+	g.Players[0].Snake = NewSnake(Position{X: 2, Y: 0})
+	if g.PlacePod("no-room", "default") {
+		t.Fatal("expected PlacePod to fail when the snake fills the board")
+	}
+}
+
+func TestGameWonWhenBoardFull(t *testing.T) {
+	g := New(4, 1)
+	g.Players[0].Snake = NewSnake(Position{X: 2, Y: 0})
+	if !g.PlacePod("last-pod", "default") {
+		t.Fatal("expected the pod to take the last free cell")
+	}
+
+	g.Tick()
+	if g.State != StateWon {
+		t.Fatalf("expected StateWon after filling the board, got %v", g.State)
+	}
+}
+
+func TestPowerUpPhaseWrapsAtEdge(t *testing.T) {
+	g := New(10, 10)
+	g.Players[0].Snake = NewSnake(Position{X: 9, Y: 5})
+	g.Players[0].Effects[PowerPhase] = 5
+
+	g.Tick()
+	if g.State != StateRunning {
+		t.Fatal("expected phase power-up to survive leaving the board")
+	}
+	if g.Players[0].Snake.Head() != (Position{X: 0, Y: 5}) {
+		t.Fatalf("expected head to wrap to (0,5), got %v", g.Players[0].Snake.Head())
+	}
+}
+
+func TestPowerUpDoublePointsAndShrink(t *testing.T) {
+	g := New(20, 20)
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	g.Players[0].Snake.Body = append(g.Players[0].Snake.Body, Position{X: 2, Y: 5}, Position{X: 1, Y: 5}, Position{X: 0, Y: 5})
+	g.Items = []Item{{Pos: Position{X: 6, Y: 5}, Kind: PowerShrink}, {Pos: Position{X: 7, Y: 5}, Kind: PowerDoublePoints}}
+	g.Pods = []Pod{{Pos: Position{X: 8, Y: 5}, Name: "p"}}
+
+	g.Tick()
+	if len(g.Players[0].Snake.Body) != 3 {
+		t.Fatalf("expected shrink to leave 3 segments, got %d", len(g.Players[0].Snake.Body))
+	}
+	g.Tick()
+	if !g.Players[0].Active(PowerDoublePoints) {
+		t.Fatal("expected double points to be active")
+	}
+	g.Tick()
+	if g.Score != 2 {
+		t.Fatalf("expected 2 points for a pod under double points, got %d", g.Score)
+	}
+	if len(g.Items) != 0 {
+		t.Fatalf("expected items to be consumed, %d left", len(g.Items))
+	}
+}
+
+func TestPowerUpExpires(t *testing.T) {
+	g := New(20, 20)
+	g.Players[0].Effects[PowerInvincible] = 2
+	g.Tick()
+	g.Tick()
+	if g.Players[0].Active(PowerInvincible) {
+		t.Fatal("expected invincibility to expire")
+	}
+}
+
+func TestDecoyEndsGameWithoutBeingEaten(t *testing.T) {
+	g := New(20, 20)
+	g.Decoys = DecoysEndGame
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	g.Pods = []Pod{{Pos: Position{X: 6, Y: 5}, Name: "precious", Protected: true}}
+
+	eaten := g.Tick()
+	if len(eaten) != 0 {
+		t.Fatalf("decoy must never be returned for deletion, got %v", eaten)
+	}
+	if g.State != StateOver {
+		t.Fatal("expected touching a decoy to end the game")
+	}
+	if len(g.DecoysHit) != 1 || g.DecoysHit[0].Name != "precious" {
+		t.Fatalf("expected decoy hit to be reported, got %v", g.DecoysHit)
+	}
+}
+
+func TestDecoyCostsPoints(t *testing.T) {
+	g := New(20, 20)
+	g.Decoys = DecoysCostPoints
+	g.Score = DecoyPenalty + 1
+	g.Players[0].Score = DecoyPenalty + 1
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	g.Pods = []Pod{{Pos: Position{X: 6, Y: 5}, Name: "precious", Protected: true}}
+
+	eaten := g.Tick()
+	if len(eaten) != 0 || g.KillCount != 0 {
+		t.Fatal("decoy must not count as a kill")
+	}
+	if g.State != StateRunning {
+		t.Fatal("expected game to continue under the points rule")
+	}
+	if g.Score != 1 {
+		t.Fatalf("expected score 1 after penalty, got %d", g.Score)
+	}
+	if g.DecoyCount() != 0 {
+		t.Fatal("expected the touched decoy to leave the board")
+	}
+}
+
+func TestDecoyCostsOnlyWhatThePlayerHad(t *testing.T) {
+	g := New(20, 20)
+	g.Decoys = DecoysCostPoints
+	g.Players = append(g.Players, &Player{Score: DecoyPenalty, Alive: true})
+	g.Players[0].Score = 1
+	g.Score = DecoyPenalty + 1
+
+	g.hitDecoy(g.Players[0], Pod{Name: "precious", Protected: true})
+	if g.Players[0].Score != 0 {
+		t.Fatalf("expected the player's score to floor at 0, got %d", g.Players[0].Score)
+	}
+	if g.Score != DecoyPenalty {
+		t.Fatalf("expected the total to lose only the player's point, got %d", g.Score)
+	}
+}
+
+func TestDecoysHaveTheirOwnCap(t *testing.T) {
+	g := New(20, 20)
+	if g.AddPod(Pod{Name: "d", Protected: true}) {
+		t.Fatal("expected no decoys while decoys are off")
+	}
+	g.Decoys = DecoysEndGame
+	for i := 0; i < g.MaxDecoys; i++ {
+		if !g.AddPod(Pod{Name: "d", Protected: true}) {
+			t.Fatal("expected decoy to be placed")
+		}
+	}
+	if !g.PlacePod("food", "default") {
+		t.Fatal("decoys should not count against MaxPods")
+	}
+}
+
+func TestPodsFleeTheSnake(t *testing.T) {
+	g := New(20, 20)
+	g.Movement = MoveFlee
+	g.MoveEvery = 1
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	g.Pods = []Pod{{Pos: Position{X: 10, Y: 5}, Name: "runner"}}
+
+	before := distance(g.Pods[0].Pos, g.Players[0].Snake.Head())
+	g.Tick()
+	after := distance(g.Pods[0].Pos, g.Players[0].Snake.Head())
+	if after <= before-1 {
+		t.Fatalf("expected fleeing pod to keep its distance, went from %d to %d", before, after)
+	}
+}
+
+func TestMovingPodsRespectObstacles(t *testing.T) {
+	g := New(3, 3)
+	g.Movement = MoveRandom
+	g.MoveEvery = 1
+	g.Players[0].Snake = &Snake{Body: []Position{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}}, Direction: Down}
+	g.Board.Walls[Position{X: 1, Y: 1}] = true
+	g.Pods = []Pod{{Pos: Position{X: 2, Y: 2}, Name: "a"}, {Pos: Position{X: 2, Y: 1}, Name: "b"}}
+
+	for i := 0; i < 20; i++ {
+		g.movePods()
+		for _, pod := range g.Pods {
+			if g.Board.IsBlocked(pod.Pos) {
+				t.Fatalf("pod %s moved onto blocked cell %v", pod.Name, pod.Pos)
+			}
+			for _, seg := range g.Players[0].Snake.Body {
+				if seg == pod.Pos {
+					t.Fatalf("pod %s moved onto the snake at %v", pod.Name, pod.Pos)
+				}
+			}
+		}
+		if g.Pods[0].Pos == g.Pods[1].Pos {
+			t.Fatal("pods moved onto each other")
+		}
+	}
+}
+
+func TestPodSpeedFromAttributes(t *testing.T) {
+	if PodSpeed(Pod{}) != 0 {
+		t.Fatal("expected idle pod to have no speed bonus")
+	}
+	if PodSpeed(Pod{Restarts: 1, CPUMillis: 500}) != 3 {
+		t.Fatal("expected one restart and half a core to give speed 3")
+	}
+	if PodSpeed(Pod{Restarts: 100}) != maxPodSpeed {
+		t.Fatal("expected speed to be capped")
+	}
+}
+
+func TestRemovePodEvaporatesWithoutPoints(t *testing.T) {
+	g := New(20, 20)
+	g.PlacePod("ghost", "default")
+
+	pod, ok := g.RemovePod("ghost", "default")
+	if !ok || pod.Name != "ghost" {
+		t.Fatal("expected pod to be removed")
+	}
+	if len(g.Pods) != 0 || g.Score != 0 || g.KillCount != 0 {
+		t.Fatal("expected an evaporated pod to leave no score behind")
+	}
+	if len(g.Fades) != 1 || g.Fades[0].Pos != pod.Pos {
+		t.Fatalf("expected a fade where the pod was, got %v", g.Fades)
+	}
+	if _, ok := g.RemovePod("ghost", "default"); ok {
+		t.Fatal("expected second removal to report not found")
+	}
+
+	for i := 0; i < FadeTicks; i++ {
+		g.Tick()
+	}
+	if len(g.Fades) != 0 {
+		t.Fatal("expected the fade to finish")
+	}
+}
+
+func TestPodPhaseRules(t *testing.T) {
+	g := New(20, 20)
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	g.Pods = []Pod{
+		{Pos: Position{X: 6, Y: 5}, Name: "leaving", Phase: PhaseTerminating},
+		{Pos: Position{X: 7, Y: 5}, Name: "crashing", Phase: PhaseCrashLoop},
+	}
+
+	if eaten := g.Tick(); len(eaten) != 0 {
+		t.Fatalf("terminating pods must not be eaten, got %v", eaten)
+	}
+	if len(g.Pods) != 2 {
+		t.Fatal("expected the terminating pod to stay on the board")
+	}
+	if g.EdibleCount() != 1 {
+		t.Fatalf("expected 1 edible pod, got %d", g.EdibleCount())
+	}
+
+	eaten := g.Tick()
+	if len(eaten) != 1 || eaten[0].Name != "crashing" {
+		t.Fatalf("expected to eat the crash-looping pod, got %v", eaten)
+	}
+	if g.Score != PhaseCrashLoop.Points() {
+		t.Fatalf("expected %d points, got %d", PhaseCrashLoop.Points(), g.Score)
+	}
+
+	if !g.SetPodPhase("leaving", "", PhaseRunning) || !g.Pods[0].Phase.Edible() {
+		t.Fatal("expected phase update to make the pod edible")
+	}
+}
+
+func TestTwoPlayersHeadOn(t *testing.T) {
+	g := New(20, 20)
+	p2, err := g.AddPlayer("p2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	p2.Snake = NewSnakeHeading(Position{X: 7, Y: 5}, Left)
+
+	g.Tick()
+	if g.Players[0].Alive || p2.Alive {
+		t.Fatal("expected a head-on collision to take out both snakes")
+	}
+	if g.State != StateOver {
+		t.Fatal("expected game over once every snake crashed")
+	}
+}
+
+func TestTwoPlayersCreditTheEater(t *testing.T) {
+	g := New(20, 20)
+	p2, _ := g.AddPlayer("p2")
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	p2.Snake = NewSnakeHeading(Position{X: 15, Y: 10}, Left)
+	g.Pods = []Pod{{Pos: Position{X: 14, Y: 10}, Name: "web"}}
+
+	eaten := g.Tick()
+	if len(eaten) != 1 || eaten[0].EatenBy != 1 {
+		t.Fatalf("expected the pod credited to player 2, got %+v", eaten)
+	}
+	if p2.Score != 1 || p2.KillCount != 1 || g.Players[0].Score != 0 {
+		t.Fatalf("expected only player 2 to score, got %d and %d", g.Players[0].Score, p2.Score)
+	}
+	if g.Score != 1 {
+		t.Fatalf("expected total score 1, got %d", g.Score)
+	}
+}
+
+func TestOneSnakeCrashingKeepsTheGameGoing(t *testing.T) {
+	g := New(20, 20)
+	p2, _ := g.AddPlayer("p2")
+	g.Players[0].Snake = NewSnake(Position{X: 19, Y: 5})
+	p2.Snake = NewSnakeHeading(Position{X: 15, Y: 10}, Left)
+
+	g.Tick()
+	if g.Players[0].Alive || !p2.Alive {
+		t.Fatal("expected only player 1 to crash")
+	}
+	if g.State != StateRunning {
+		t.Fatal("expected the game to continue while a snake is alive")
+	}
+	for len(g.Players) < MaxPlayers {
+		if _, err := g.AddPlayer("more"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := g.AddPlayer("one too many"); err == nil {
+		t.Fatal("expected an error beyond MaxPlayers")
+	}
+}
+
+// botGame builds a game where a lone bot replaces the human snake.
+func botGame(w, h int, s Strategy) *Game {
+	g := New(w, h)
+	g.Players[0].Strategy = s
+	return g
+}
+
+func TestBotsEatThePod(t *testing.T) {
+	for _, s := range Strategies() {
+		g := botGame(20, 20, s)
+		g.Pods = []Pod{{Pos: Position{X: 2, Y: 15}, Name: "web"}}
+
+		ate := false
+		for i := 0; i < 20*20*2 && !ate; i++ {
+			ate = len(g.Tick()) > 0
+			if g.State != StateRunning {
+				t.Fatalf("%s: bot crashed after %d ticks", s.Name(), i)
+			}
+		}
+		if !ate {
+			t.Fatalf("%s: bot never reached the pod", s.Name())
+		}
+	}
+}
+
+func TestBotsRouteAroundDecoys(t *testing.T) {
+	g := botGame(20, 20, ShortestPath)
+	g.Decoys = DecoysEndGame
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	g.Pods = []Pod{
+		{Pos: Position{X: 6, Y: 5}, Name: "precious", Protected: true},
+		{Pos: Position{X: 8, Y: 5}, Name: "web"},
+	}
+
+	for i := 0; i < 10 && len(g.Pods) > 1; i++ {
+		g.Tick()
+	}
+	if g.State != StateRunning || len(g.DecoysHit) != 0 || len(g.Pods) != 1 || !g.Pods[0].Protected {
+		t.Fatalf("expected the bot to eat around the decoy, state %v pods %+v", g.State, g.Pods)
+	}
+}
+
+func TestHamiltonianBotNeverCrashes(t *testing.T) {
+	g := botGame(10, 8, Hamiltonian)
+	spawned := 0
+	for i := 0; i < 2000 && g.State == StateRunning; i++ {
+		if g.EdibleCount() == 0 && g.PlacePod("p", "default") {
+			spawned++
+		}
+		g.Tick()
+	}
+	if g.State == StateOver {
+		t.Fatalf("hamiltonian bot crashed at length %d", g.Players[0].Snake.Length())
+	}
+	if g.Players[0].KillCount < 10 {
+		t.Fatalf("expected the bot to keep eating, ate %d of %d", g.Players[0].KillCount, spawned)
+	}
+}
+
+func TestBotsAreDeterministic(t *testing.T) {
+	for _, s := range Strategies() {
+		play := func() []Position {
+			g := botGame(20, 20, s)
+			g.Pods = []Pod{{Pos: Position{X: 15, Y: 3}, Name: "a"}, {Pos: Position{X: 3, Y: 15}, Name: "b"}}
+			var heads []Position
+			for i := 0; i < 100 && g.State == StateRunning; i++ {
+				g.Tick()
+				heads = append(heads, g.Players[0].Snake.Head())
+			}
+			return heads
+		}
+		a, b := play(), play()
+		if len(a) != len(b) {
+			t.Fatalf("%s: runs differ in length", s.Name())
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				t.Fatalf("%s: runs diverge at tick %d", s.Name(), i)
+			}
+		}
+	}
+}
+
+func TestHumanCrashEndsGameAgainstBot(t *testing.T) {
+	g := New(20, 20)
+	if _, err := g.AddBot("AI", ShortestPath); err != nil {
+		t.Fatal(err)
+	}
+	g.Players[0].Snake = NewSnake(Position{X: 19, Y: 5})
+
+	g.Tick()
+	if g.State != StateOver {
+		t.Fatal("expected game over once the only human crashed")
+	}
+}
+
+func TestTimeAttackEndsWhenTheClockRunsOut(t *testing.T) {
+	// A Hamiltonian bot circles safely for as long as the clock runs.
+	g := botGame(10, 8, Hamiltonian)
+	g.Mode = ModeTimeAttack
+
+	ticks := int(TimeAttackLimit / g.TickRate())
+	for i := 0; i < ticks-1; i++ {
+		g.Tick()
+	}
+	if g.State != StateRunning || g.TimeLeft() <= 0 {
+		t.Fatalf("expected time left before the limit, state %v left %v", g.State, g.TimeLeft())
+	}
+	g.Tick()
+	if g.State != StateOver || g.TimeLeft() != 0 {
+		t.Fatalf("expected time up after %v, state %v", TimeAttackLimit, g.State)
+	}
+}
+
+func TestSurvivalPodsHardenIntoWalls(t *testing.T) {
+	g := New(200, 20)
+	g.Mode = ModeSurvival
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 15})
+	web := Position{X: 1, Y: 1}
+	g.Pods = []Pod{{Pos: web, Name: "web"}, {Pos: Position{X: 3, Y: 1}, Name: "precious", Protected: true}}
+
+	for i := 0; i < SurvivalPodTicks && len(g.Hardened) == 0; i++ {
+		g.Tick()
+	}
+	if len(g.Hardened) != 1 || g.Hardened[0].Name != "web" {
+		t.Fatalf("expected web to harden, got %+v", g.Hardened)
+	}
+	if !g.Board.IsWall(web) {
+		t.Fatal("expected the hardened pod to leave a wall")
+	}
+	if len(g.Pods) != 1 || !g.Pods[0].Protected {
+		t.Fatal("expected decoys never to harden")
+	}
+}
+
+func TestZenNeverEnds(t *testing.T) {
+	g := New(10, 10)
+	g.Mode = ModeZen
+	g.Decoys = DecoysEndGame
+	g.Players[0].Snake = NewSnake(Position{X: 9, Y: 5})
+	g.Pods = []Pod{{Pos: Position{X: 1, Y: 5}, Name: "precious", Protected: true}}
+
+	g.Tick()
+	if g.Players[0].Snake.Head() != (Position{X: 0, Y: 5}) {
+		t.Fatalf("expected zen to wrap at the edge, head at %v", g.Players[0].Snake.Head())
+	}
+	g.Tick()
+	if g.State != StateRunning {
+		t.Fatal("expected a decoy not to end a zen game")
+	}
+
+	// Turn back into its own body.
+	g.Players[0].Snake.Body = []Position{{X: 5, Y: 5}, {X: 5, Y: 6}, {X: 4, Y: 6}, {X: 4, Y: 5}, {X: 4, Y: 4}}
+	g.Players[0].Snake.Direction = Left
+	g.Tick()
+	if g.State != StateRunning || !g.Players[0].Alive {
+		t.Fatal("expected self collision not to end a zen game")
+	}
+}
+
+func TestLongestSurvivesShrinking(t *testing.T) {
+	g := New(20, 20)
+	p := g.Players[0]
+	p.Snake = NewSnake(Position{X: 5, Y: 5})
+	g.Pods = []Pod{{Pos: Position{X: 6, Y: 5}, Name: "a"}, {Pos: Position{X: 7, Y: 5}, Name: "b"}}
+	g.Tick()
+	g.Tick()
+	if p.Longest != 5 {
+		t.Fatalf("expected longest 5 after two pods, got %d", p.Longest)
+	}
+	p.applyPowerUp(PowerShrink)
+	g.Tick()
+	if p.Longest != 5 || p.Snake.Length() >= 5 {
+		t.Fatalf("expected longest to stay 5 after shrinking to %d, got %d", p.Snake.Length(), p.Longest)
+	}
+}
+
+func TestNearestPod(t *testing.T) {
+	g := New(20, 20)
+	if _, ok := g.NearestPod(Position{X: 5, Y: 5}); ok {
+		t.Fatal("expected no pod on an empty board")
+	}
+	g.Pods = []Pod{
+		{Pos: Position{X: 15, Y: 15}, Name: "far"},
+		{Pos: Position{X: 8, Y: 4}, Name: "near", Protected: true},
+		{Pos: Position{X: 2, Y: 6}, Name: "tied"},
+	}
+	if pod, ok := g.NearestPod(Position{X: 5, Y: 5}); !ok || pod.Name != "near" {
+		t.Fatalf("expected the first of the nearest pods, got %+v", pod)
+	}
+	if pod, ok := g.PodAt(Position{X: 15, Y: 15}); !ok || pod.Name != "far" {
+		t.Fatalf("expected the pod at 15,15, got %+v", pod)
+	}
+	if _, ok := g.PodAt(Position{X: 0, Y: 0}); ok {
+		t.Fatal("expected no pod at 0,0")
+	}
+}
+
+func TestParseOpponent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Strategy
+	}{
+		{"off", nil},
+		{"easy", Greedy},
+		{"normal", ShortestPath},
+		{"hard", Hamiltonian},
+		{"bfs", ShortestPath},
+		{"hamiltonian", Hamiltonian},
+	}
+	for _, tt := range tests {
+		got, err := ParseOpponent(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseOpponent(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseOpponent("nightmare"); err == nil {
+		t.Error("expected an error for an unknown opponent")
+	}
+	if got := OpponentLevel(Hamiltonian); got != "hard" {
+		t.Errorf("OpponentLevel(Hamiltonian) = %q, want hard", got)
+	}
+}
+
+func TestSpareTakesBackTheKill(t *testing.T) {
+	g := New(20, 20)
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	g.Pods = []Pod{{Pos: Position{X: 6, Y: 5}, Name: "web"}}
+	eaten := g.Tick()
+	if len(eaten) != 1 || g.Score == 0 {
+		t.Fatalf("expected the pod to be eaten and scored, got %v with score %d", eaten, g.Score)
+	}
+	g.Spare(eaten[0])
+	if g.Score != 0 || g.KillCount != 0 || g.Players[0].Score != 0 || g.Players[0].KillCount != 0 {
+		t.Fatalf("expected nothing scored after sparing, got score %d kills %d", g.Score, g.KillCount)
+	}
+}
+
+func TestSpareTakesBackTheLevel(t *testing.T) {
+	g := New(20, 20)
+	g.SetDifficulty(Normal)
+	g.KillCount = Normal.KillsPerLevel - 1
+	g.Players[0].KillCount = Normal.KillsPerLevel - 1
+	g.Players[0].Snake = NewSnake(Position{X: 5, Y: 5})
+	g.Pods = []Pod{{Pos: Position{X: 6, Y: 5}, Name: "web"}}
+	eaten := g.Tick()
+	if len(eaten) != 1 || g.Level != 2 {
+		t.Fatalf("expected the kill to reach level 2, got level %d", g.Level)
+	}
+	g.Spare(eaten[0])
+	if g.Level != 1 || g.MaxPods != Normal.BaseMaxPods {
+		t.Fatalf("expected level 1 with %d pods after sparing, got level %d with %d", Normal.BaseMaxPods, g.Level, g.MaxPods)
+	}
+}
+
+func TestParsePodPhase(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  PodPhase
+		shown bool
+	}{
+		{"Running", PhaseRunning, true},
+		{"Pending", PhasePending, true},
+		{"CrashLoopBackOff", PhaseCrashLoop, true},
+		{"Terminating", PhaseTerminating, true},
+		{"Succeeded", PhaseRunning, false},
+		{"", PhaseRunning, false},
+	}
+	for _, tt := range tests {
+		got, shown := ParsePodPhase(tt.in)
+		if got != tt.want || shown != tt.shown {
+			t.Errorf("ParsePodPhase(%q) = %v, %v; want %v, %v", tt.in, got, shown, tt.want, tt.shown)
+		}
+	}
+}
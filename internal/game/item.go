@@ -0,0 +1,102 @@
+package game
+
+// PowerUp is the effect an item grants when the snake eats it.
+type PowerUp int
+
+const (
+	PowerInvincible   PowerUp = iota // survive self-collisions and hazards
+	PowerPhase                       // pass through walls and wrap at the edges
+	PowerDoublePoints                // pods are worth two points
+	PowerShrink                      // drop segments off the tail
+)
+
+// String returns the name shown in the UI for the power-up.
+func (p PowerUp) String() string {
+	switch p {
+	case PowerInvincible:
+		return "invincible"
+	case PowerPhase:
+		return "phase"
+	case PowerDoublePoints:
+		return "double points"
+	case PowerShrink:
+		return "shrink"
+	}
+	return "unknown"
+}
+
+const (
+	// powerUpTicks is how long a timed power-up lasts.
+	powerUpTicks = 60
+	// shrinkBy is how many segments a shrink power-up removes.
+	shrinkBy = 3
+	// minSnakeLength is the shortest a shrink can leave the snake.
+	minSnakeLength = 3
+)
+
+// Item is a non-pod cluster resource displayed on the board as a power-up.
+// Items are read from the cluster but never modified.
+type Item struct {
+	Pos       Position
+	Kind      PowerUp
+	Name      string
+	Namespace string
+}
+
+// AddItem places the given item at a random free position.
+// Returns true if the item was placed, false if the board is full or
+// already holds MaxItems items.
+func (g *Game) AddItem(item Item) bool {
+	if len(g.Items) >= g.MaxItems {
+		return false
+	}
+	pos, ok := g.Board.RandomPosition(g.occupied())
+	if !ok {
+		return false
+	}
+	item.Pos = pos
+	g.Items = append(g.Items, item)
+	return true
+}
+
+// tickEffects counts down every active power-up by one tick.
+func (p *Player) tickEffects() {
+	for pu, left := range p.Effects {
+		if left <= 1 {
+			delete(p.Effects, pu)
+		} else {
+			p.Effects[pu] = left - 1
+		}
+	}
+}
+
+// collectItems lets the player eat any item under its head and applies the
+// power-up to that player.
+func (g *Game) collectItems(p *Player, head Position) []Item {
+	var collected []Item
+	remaining := g.Items[:0]
+	for _, item := range g.Items {
+		if item.Pos == head {
+			collected = append(collected, item)
+			p.applyPowerUp(item.Kind)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+	g.Items = remaining
+	return collected
+}
+
+func (p *Player) applyPowerUp(pu PowerUp) {
+	if pu != PowerShrink {
+		p.Effects[pu] = powerUpTicks
+		return
+	}
+	keep := len(p.Snake.Body) - shrinkBy
+	if keep < minSnakeLength {
+		keep = minSnakeLength
+	}
+	if keep < len(p.Snake.Body) {
+		p.Snake.Body = p.Snake.Body[:keep]
+	}
+}
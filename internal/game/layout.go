@@ -0,0 +1,282 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Layout controls how the board is partitioned and where pods spawn.
+type Layout int
+
+const (
+	LayoutRandom     Layout = iota // open board, pods spawn anywhere
+	LayoutNodes                    // one room per node, pods spawn in their node's room
+	LayoutNamespaces               // one zone per namespace, pods spawn in their namespace's zone
+)
+
+// String returns the name used for the layout in menus and flags.
+func (l Layout) String() string {
+	switch l {
+	case LayoutNodes:
+		return "nodes"
+	case LayoutNamespaces:
+		return "namespaces"
+	default:
+		return "random"
+	}
+}
+
+// ParseLayout converts a flag value into a Layout.
+func ParseLayout(s string) (Layout, error) {
+	switch s {
+	case "", "random":
+		return LayoutRandom, nil
+	case "nodes":
+		return LayoutNodes, nil
+	case "namespaces":
+		return LayoutNamespaces, nil
+	}
+	return LayoutRandom, fmt.Errorf("unknown layout %q (want random, nodes or namespaces)", s)
+}
+
+// Node describes a cluster node for the node layout.
+type Node struct {
+	Name   string
+	Hazard bool // cordoned or NotReady
+}
+
+// Rect is a rectangular area of the board. Min is inclusive, Max exclusive.
+type Rect struct {
+	Min Position
+	Max Position
+}
+
+// Width returns the number of columns in the rectangle.
+func (r Rect) Width() int { return r.Max.X - r.Min.X }
+
+// Height returns the number of rows in the rectangle.
+func (r Rect) Height() int { return r.Max.Y - r.Min.Y }
+
+// Contains returns true if p lies inside the rectangle.
+func (r Rect) Contains(p Position) bool {
+	return p.X >= r.Min.X && p.X < r.Max.X && p.Y >= r.Min.Y && p.Y < r.Max.Y
+}
+
+// Room is a walled-off region of the board belonging to one node or
+// namespace.
+type Room struct {
+	Name   string
+	Bounds Rect
+	Hazard bool
+}
+
+// minRoomWidth keeps rooms wide enough for the starting snake.
+const minRoomWidth = 3
+
+// SplitRooms partitions the board into a grid of rooms, one per entry,
+// separated by walls with a door between each pair of neighbouring rooms.
+// Hazard rooms are sealed and sorted to the end of the grid so the safe
+// rooms always stay connected. Entries that do not fit get no room.
+func (b *Board) SplitRooms(nodes []Node) {
+	b.Walls = make(map[Position]bool)
+	b.Rooms = nil
+	if len(nodes) == 0 {
+		return
+	}
+
+	ordered := make([]Node, len(nodes))
+	copy(ordered, nodes)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return !ordered[i].Hazard && ordered[j].Hazard
+	})
+
+	// If every node is a hazard there is nowhere safe to play.
+	if ordered[0].Hazard {
+		for i := range ordered {
+			ordered[i].Hazard = false
+		}
+	}
+
+	maxCols := (b.Width + 1) / (minRoomWidth + 1)
+	maxRows := (b.Height + 1) / 2
+	if maxCols < 1 || maxRows < 1 {
+		return
+	}
+	cols := int(math.Ceil(math.Sqrt(float64(len(ordered)))))
+	if cols > maxCols {
+		cols = maxCols
+	}
+	rows := (len(ordered) + cols - 1) / cols
+	if rows > maxRows {
+		rows = maxRows
+	}
+	if len(ordered) > cols*rows {
+		ordered = ordered[:cols*rows]
+	}
+
+	xs := splitSpan(b.Width, cols)
+	ys := splitSpan(b.Height, rows)
+
+	// Walls run along every internal boundary.
+	for _, span := range xs[1:] {
+		for y := 0; y < b.Height; y++ {
+			b.Walls[Position{X: span[0] - 1, Y: y}] = true
+		}
+	}
+	for _, span := range ys[1:] {
+		for x := 0; x < b.Width; x++ {
+			b.Walls[Position{X: x, Y: span[0] - 1}] = true
+		}
+	}
+
+	grid := make([][]*Room, rows)
+	for r := range grid {
+		grid[r] = make([]*Room, cols)
+	}
+	for i, n := range ordered {
+		r, c := i/cols, i%cols
+		b.Rooms = append(b.Rooms, Room{
+			Name:   n.Name,
+			Hazard: n.Hazard,
+			Bounds: Rect{
+				Min: Position{X: xs[c][0], Y: ys[r][0]},
+				Max: Position{X: xs[c][1], Y: ys[r][1]},
+			},
+		})
+	}
+	for i := range b.Rooms {
+		grid[i/cols][i%cols] = &b.Rooms[i]
+	}
+
+	// Open a door between neighbours unless either side is a hazard.
+	// Grid slots without a node count as open floor.
+	sealed := func(room *Room) bool { return room != nil && room.Hazard }
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if c+1 < cols && !sealed(grid[r][c]) && !sealed(grid[r][c+1]) {
+				y := (ys[r][0] + ys[r][1]) / 2
+				delete(b.Walls, Position{X: xs[c+1][0] - 1, Y: y})
+			}
+			if r+1 < rows && !sealed(grid[r][c]) && !sealed(grid[r+1][c]) {
+				x := (xs[c][0] + xs[c][1]) / 2
+				delete(b.Walls, Position{X: x, Y: ys[r+1][0] - 1})
+			}
+		}
+	}
+}
+
+// splitSpan divides length cells into n spans separated by one-cell walls
+// and returns each span as [start, end).
+func splitSpan(length, n int) [][2]int {
+	usable := length - (n - 1)
+	base, extra := usable/n, usable%n
+	spans := make([][2]int, n)
+	start := 0
+	for i := range spans {
+		size := base
+		if i < extra {
+			size++
+		}
+		spans[i] = [2]int{start, start + size}
+		start += size + 1
+	}
+	return spans
+}
+
+// Room returns the room with the given name, if there is one.
+func (b *Board) Room(name string) (Room, bool) {
+	for _, r := range b.Rooms {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Room{}, false
+}
+
+// IsWall returns true if the position is an internal wall.
+func (b *Board) IsWall(p Position) bool {
+	return b.Walls[p]
+}
+
+// IsHazard returns true if the position lies inside a hazard room.
+func (b *Board) IsHazard(p Position) bool {
+	for _, r := range b.Rooms {
+		if r.Hazard && r.Bounds.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBlocked returns true if the snake cannot occupy the position.
+func (b *Board) IsBlocked(p Position) bool {
+	return b.IsOutOfBounds(p) || b.IsWall(p) || b.IsHazard(p)
+}
+
+// SetTopology switches the game to the node layout: the board is split
+// into one room per node and the snake is moved into the first safe room.
+func (g *Game) SetTopology(nodes []Node) {
+	g.Layout = LayoutNodes
+	g.Board.SplitRooms(nodes)
+	g.settleIntoRooms()
+}
+
+// SetZones switches the game to the namespace layout: the board is split
+// into one zone per namespace and the snake is moved into the first zone.
+func (g *Game) SetZones(namespaces []string) {
+	zones := make([]Node, 0, len(namespaces))
+	for _, ns := range namespaces {
+		zones = append(zones, Node{Name: ns})
+	}
+	g.Layout = LayoutNamespaces
+	g.Board.SplitRooms(zones)
+	g.settleIntoRooms()
+}
+
+// roomFor returns the room a pod should spawn in for the current layout.
+func (g *Game) roomFor(pod Pod) (Room, bool) {
+	switch g.Layout {
+	case LayoutNodes:
+		return g.Board.Room(pod.Node)
+	case LayoutNamespaces:
+		return g.Board.Room(pod.Namespace)
+	}
+	return Room{}, false
+}
+
+// settleIntoRooms moves each snake into its own safe room (sharing rooms
+// when there are not enough) and drops any pods left on blocked cells after
+// the board was split.
+func (g *Game) settleIntoRooms() {
+	var safe []Room
+	for _, room := range g.Board.Rooms {
+		if !room.Hazard && room.Bounds.Width() >= minRoomWidth {
+			safe = append(safe, room)
+		}
+	}
+	for i, p := range g.Players {
+		if len(safe) == 0 {
+			break
+		}
+		room := safe[i%len(safe)]
+		offset := room.Bounds.Width() / 2
+		if offset < minRoomWidth-1 {
+			offset = minRoomWidth - 1
+		}
+		y := room.Bounds.Min.Y + room.Bounds.Height()/2
+		if i >= len(safe) {
+			// Sharing a room: stack snakes on different rows.
+			y = room.Bounds.Min.Y + (room.Bounds.Height()*(i/len(safe)+1))/(MaxPlayers+1)
+		}
+		p.Snake = NewSnake(Position{X: room.Bounds.Min.X + offset, Y: y})
+	}
+
+	remaining := g.Pods[:0]
+	for _, pod := range g.Pods {
+		if !g.Board.IsBlocked(pod.Pos) {
+			remaining = append(remaining, pod)
+		}
+	}
+	g.Pods = remaining
+}
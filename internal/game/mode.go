@@ -0,0 +1,81 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// Mode selects the rules a game is played by.
+type Mode int
+
+const (
+	ModeClassic    Mode = iota // play until you crash
+	ModeTimeAttack             // eat as much as possible before the clock runs out
+	ModeSurvival               // uneaten pods harden into walls
+	ModeZen                    // no crashing, the board wraps around
+)
+
+// Modes returns every mode in menu order.
+func Modes() []Mode {
+	return []Mode{ModeClassic, ModeTimeAttack, ModeSurvival, ModeZen}
+}
+
+// String returns the name used for the mode in menus, flags and high
+// scores.
+func (m Mode) String() string {
+	switch m {
+	case ModeTimeAttack:
+		return "time-attack"
+	case ModeSurvival:
+		return "survival"
+	case ModeZen:
+		return "zen"
+	default:
+		return "classic"
+	}
+}
+
+// ParseMode converts a flag value into a Mode.
+func ParseMode(s string) (Mode, error) {
+	if s == "" {
+		return ModeClassic, nil
+	}
+	for _, m := range Modes() {
+		if m.String() == s {
+			return m, nil
+		}
+	}
+	return ModeClassic, fmt.Errorf("unknown mode %q (want classic, time-attack, survival or zen)", s)
+}
+
+const (
+	// TimeAttackLimit is how long a time attack game lasts.
+	TimeAttackLimit = 60 * time.Second
+	// SurvivalPodTicks is how long a pod stays edible in survival mode
+	// before it turns into a wall.
+	SurvivalPodTicks = 100
+)
+
+// TimeLeft returns the time remaining in a time attack game, or zero in
+// other modes.
+func (g *Game) TimeLeft() time.Duration {
+	if g.Mode != ModeTimeAttack || g.Elapsed >= TimeAttackLimit {
+		return 0
+	}
+	return TimeAttackLimit - g.Elapsed
+}
+
+// hardenPods turns pods left uneaten for SurvivalPodTicks into walls.
+// Decoys never harden. The hardened pods are reported in g.Hardened.
+func (g *Game) hardenPods() {
+	remaining := g.Pods[:0]
+	for _, pod := range g.Pods {
+		if !pod.Protected && g.Ticks-pod.Born >= SurvivalPodTicks {
+			g.Board.Walls[pod.Pos] = true
+			g.Hardened = append(g.Hardened, pod)
+			continue
+		}
+		remaining = append(remaining, pod)
+	}
+	g.Pods = remaining
+}
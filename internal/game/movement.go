@@ -0,0 +1,149 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// PodMovement controls whether pods move around the board on their own.
+type PodMovement int
+
+const (
+	MoveNone   PodMovement = iota // pods sit still until eaten
+	MoveRandom                    // pods wander to a random free neighbour
+	MoveFlee                      // pods step away from the snake's head
+)
+
+// String returns the name used for the movement in menus and flags.
+func (m PodMovement) String() string {
+	switch m {
+	case MoveRandom:
+		return "random"
+	case MoveFlee:
+		return "flee"
+	default:
+		return "off"
+	}
+}
+
+// ParsePodMovement converts a flag value into a PodMovement.
+func ParsePodMovement(s string) (PodMovement, error) {
+	switch s {
+	case "", "off":
+		return MoveNone, nil
+	case "random":
+		return MoveRandom, nil
+	case "flee":
+		return MoveFlee, nil
+	}
+	return MoveNone, fmt.Errorf("unknown pod movement %q (want off, random or flee)", s)
+}
+
+const (
+	// defaultMoveEvery is how many ticks a pod with no speed bonus waits
+	// between steps.
+	defaultMoveEvery = 6
+	// maxPodSpeed caps the speed bonus so pods never outrun the snake.
+	maxPodSpeed = 4
+)
+
+// PodSpeed returns a pod's speed bonus: one per container restart and one
+// per quarter core of CPU requested, up to maxPodSpeed.
+func PodSpeed(p Pod) int {
+	speed := p.Restarts + int(p.CPUMillis/250)
+	if speed > maxPodSpeed {
+		speed = maxPodSpeed
+	}
+	return speed
+}
+
+// moveInterval returns how many ticks the pod waits between steps.
+func (g *Game) moveInterval(p Pod) int {
+	interval := g.MoveEvery - PodSpeed(p)
+	if interval < 1 {
+		interval = 1
+	}
+	return interval
+}
+
+// movePods steps every pod that is due this tick. Pods never move into
+// walls, hazards, the snake, items or each other.
+func (g *Game) movePods() {
+	if g.Movement == MoveNone {
+		return
+	}
+
+	taken := make(map[Position]bool)
+	for _, p := range g.occupied() {
+		taken[p] = true
+	}
+	for i := range g.Pods {
+		pod := &g.Pods[i]
+		if pod.Protected || g.Ticks%g.moveInterval(*pod) != 0 {
+			continue
+		}
+
+		var options []Position
+		for _, d := range []Direction{Up, Down, Left, Right} {
+			next := step(pod.Pos, d)
+			if !taken[next] && !g.Board.IsBlocked(next) {
+				options = append(options, next)
+			}
+		}
+		if len(options) == 0 {
+			continue
+		}
+
+		next := options[rand.Intn(len(options))]
+		if g.Movement == MoveFlee {
+			next = pod.Pos
+			best := g.nearestHead(pod.Pos)
+			for _, o := range options {
+				if d := g.nearestHead(o); d > best {
+					next, best = o, d
+				}
+			}
+		}
+
+		delete(taken, pod.Pos)
+		taken[next] = true
+		pod.Pos = next
+	}
+}
+
+// nearestHead returns the distance from p to the closest living snake head.
+func (g *Game) nearestHead(p Position) int {
+	best := -1
+	for _, pl := range g.Alive() {
+		if d := distance(p, pl.Snake.Head()); best < 0 || d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+// step returns the neighbouring position in the given direction.
+func step(p Position, d Direction) Position {
+	switch d {
+	case Up:
+		return Position{X: p.X, Y: p.Y - 1}
+	case Down:
+		return Position{X: p.X, Y: p.Y + 1}
+	case Left:
+		return Position{X: p.X - 1, Y: p.Y}
+	default:
+		return Position{X: p.X + 1, Y: p.Y}
+	}
+}
+
+// distance returns the Manhattan distance between two positions.
+func distance(a, b Position) int {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	return dx + dy
+}
@@ -0,0 +1,76 @@
+package game
+
+// PodPhase is the lifecycle state of a pod on the board. The zero value is
+// Running so pods placed without a phase behave as before.
+type PodPhase int
+
+const (
+	PhaseRunning     PodPhase = iota
+	PhasePending              // not started yet
+	PhaseCrashLoop            // in CrashLoopBackOff
+	PhaseTerminating          // already on its way out
+)
+
+// String returns the name shown in the UI for the phase.
+func (p PodPhase) String() string {
+	switch p {
+	case PhasePending:
+		return "pending"
+	case PhaseCrashLoop:
+		return "crashloop"
+	case PhaseTerminating:
+		return "terminating"
+	}
+	return "running"
+}
+
+// Edible returns true if a pod in this phase can be eaten. Terminating pods
+// are already going away, so the snake slides over them.
+func (p PodPhase) Edible() bool {
+	return p != PhaseTerminating
+}
+
+// Points returns the base score for eating a pod in this phase. Catching a
+// pod before it starts is worth a little more, and putting a crash-looping
+// pod out of its misery more still.
+func (p PodPhase) Points() int {
+	switch p {
+	case PhasePending:
+		return 2
+	case PhaseCrashLoop:
+		return 3
+	case PhaseTerminating:
+		return 0
+	}
+	return 1
+}
+
+// ParsePodPhase maps a pod phase as the cluster reports it (Running,
+// Pending, CrashLoopBackOff or Terminating) onto the board. The second
+// result is false for phases that are never shown (finished or unknown
+// pods).
+func ParsePodPhase(phase string) (PodPhase, bool) {
+	switch phase {
+	case "Running":
+		return PhaseRunning, true
+	case "Pending":
+		return PhasePending, true
+	case "CrashLoopBackOff":
+		return PhaseCrashLoop, true
+	case "Terminating":
+		return PhaseTerminating, true
+	}
+	return PhaseRunning, false
+}
+
+// SetPodPhase updates the phase of a pod on the board.
+// Returns true if the pod was found.
+func (g *Game) SetPodPhase(name, namespace string, phase PodPhase) bool {
+	for i := range g.Pods {
+		if g.Pods[i].Name == name && g.Pods[i].Namespace == namespace {
+			g.Pods[i].Phase = phase
+			return true
+		}
+	}
+	return false
+}
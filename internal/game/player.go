@@ -0,0 +1,131 @@
+package game
+
+import "fmt"
+
+// MaxPlayers is how many snakes, human or bot, can share one board.
+const MaxPlayers = 4
+
+// Player is one snake on the board together with the score it earned and
+// the power-ups it is enjoying.
+type Player struct {
+	Name      string
+	Snake     *Snake
+	Score     int
+	KillCount int
+	Alive     bool
+	Effects   map[PowerUp]int // active power-ups and their remaining ticks
+	Strategy  Strategy        // steers the snake for a bot, nil for a human
+	Longest   int             // longest the snake has been
+}
+
+// newPlayer creates a living player with a snake at the given start.
+func newPlayer(name string, snake *Snake) *Player {
+	return &Player{
+		Name:    name,
+		Snake:   snake,
+		Alive:   true,
+		Effects: make(map[PowerUp]int),
+		Longest: snake.Length(),
+	}
+}
+
+// Active returns true while a timed power-up is in effect for the player.
+func (p *Player) Active(pu PowerUp) bool {
+	return p.Effects[pu] > 0
+}
+
+// AddPlayer adds another snake to the game. The second snake starts on the
+// right side of the board heading left; further snakes take the upper left
+// and lower right. Returns an error once MaxPlayers is reached.
+func (g *Game) AddPlayer(name string) (*Player, error) {
+	i := len(g.Players)
+	if i >= MaxPlayers {
+		return nil, fmt.Errorf("at most %d players", MaxPlayers)
+	}
+	w, h := g.Board.Width, g.Board.Height
+	start, heading := Position{X: w - 1 - w/4, Y: h / 2}, Left
+	switch i {
+	case 2:
+		start, heading = Position{X: w / 4, Y: h / 4}, Right
+	case 3:
+		start = Position{X: w - 1 - w/4, Y: h - 1 - h/4}
+	}
+	p := newPlayer(name, NewSnakeHeading(start, heading))
+	g.Players = append(g.Players, p)
+	if len(g.Board.Rooms) > 0 {
+		g.settleIntoRooms()
+	}
+	return p, nil
+}
+
+// Alive returns the players still on the board.
+func (g *Game) Alive() []*Player {
+	var alive []*Player
+	for _, p := range g.Players {
+		if p.Alive {
+			alive = append(alive, p)
+		}
+	}
+	return alive
+}
+
+// over returns true once the game has nobody left to play it: every human
+// has crashed, or every snake when only bots are playing.
+func (g *Game) over() bool {
+	humans := g.hasHumans()
+	for _, p := range g.Alive() {
+		if p.Strategy == nil || !humans {
+			return false
+		}
+	}
+	return true
+}
+
+// hasHumans returns true if any player, alive or not, is a human.
+func (g *Game) hasHumans() bool {
+	for _, p := range g.Players {
+		if p.Strategy == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// snakeAt returns true if any living snake other than skip has a segment at
+// pos. Pass skip=nil to check every snake.
+func (g *Game) snakeAt(pos Position, skip *Player) bool {
+	for _, p := range g.Players {
+		if p == skip || !p.Alive {
+			continue
+		}
+		for _, seg := range p.Snake.Body {
+			if seg == pos {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// crashed returns true if the player's head hit something deadly. Nothing
+// is deadly in zen mode.
+func (g *Game) crashed(p *Player) bool {
+	if g.Mode == ModeZen {
+		return false
+	}
+	head := p.Snake.Head()
+	invincible := p.Active(PowerInvincible)
+
+	if g.Board.IsOutOfBounds(head) ||
+		(g.Board.IsWall(head) && !p.Active(PowerPhase)) ||
+		(g.Board.IsHazard(head) && !invincible) {
+		return true
+	}
+
+	if p.Snake.CollidesWithSelf() && !invincible {
+		return true
+	}
+
+	// Running into another snake, head-on included.
+	return g.snakeAt(head, p) && !invincible
+}
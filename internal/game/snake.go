@@ -1,21 +1,29 @@
 package game
 
+// maxQueuedTurns bounds how many key presses are buffered between ticks.
+const maxQueuedTurns = 3
+
 // Snake holds the state of the snake on the board.
 type Snake struct {
 	Body      []Position
-	Direction Direction
+	Direction Direction // direction of the last move
 	Growing   bool
+	turns     []Direction // queued turns, applied one per Move
 }
 
 // NewSnake creates a snake starting at the given position, heading right.
 func NewSnake(start Position) *Snake {
+	return NewSnakeHeading(start, Right)
+}
+
+// NewSnakeHeading creates a snake starting at the given position with its
+// body trailing behind it, heading in direction d.
+func NewSnakeHeading(start Position, d Direction) *Snake {
+	back := d.Opposite()
+	tail1 := step(start, back)
 	return &Snake{
-		Body: []Position{
-			start,
-			{X: start.X - 1, Y: start.Y},
-			{X: start.X - 2, Y: start.Y},
-		},
-		Direction: Right,
+		Body:      []Position{start, tail1, step(tail1, back)},
+		Direction: d,
 	}
 }
 
@@ -24,23 +32,27 @@ func (s *Snake) Head() Position {
 	return s.Body[0]
 }
 
-// Move advances the snake one step in its current direction.
-// If Growing is true, the tail is not removed (the snake gets longer).
-func (s *Snake) Move() {
-	head := s.Head()
-	var next Position
+// Length returns the snake's length including any growth still pending.
+func (s *Snake) Length() int {
+	if s.Growing {
+		return len(s.Body) + 1
+	}
+	return len(s.Body)
+}
 
-	switch s.Direction {
-	case Up:
-		next = Position{X: head.X, Y: head.Y - 1}
-	case Down:
-		next = Position{X: head.X, Y: head.Y + 1}
-	case Left:
-		next = Position{X: head.X - 1, Y: head.Y}
-	case Right:
-		next = Position{X: head.X + 1, Y: head.Y}
+// Move applies the next queued turn, if any, and advances the snake one
+// step. If Growing is true, the tail is not removed (the snake gets longer).
+func (s *Snake) Move() {
+	if len(s.turns) > 0 {
+		next := s.turns[0]
+		s.turns = s.turns[1:]
+		if next != s.Direction.Opposite() {
+			s.Direction = next
+		}
 	}
 
+	next := step(s.Head(), s.Direction)
+
 	s.Body = append([]Position{next}, s.Body...)
 
 	if s.Growing {
@@ -55,14 +67,19 @@ func (s *Snake) Grow() {
 	s.Growing = true
 }
 
-// SetDirection changes direction, preventing 180-degree reversal.
+// SetDirection queues a turn to be applied on a later Move, so several
+// quick presses between ticks each take effect in order. Turns that would
+// reverse or repeat the previously queued direction are dropped, as are
+// presses beyond maxQueuedTurns.
 func (s *Snake) SetDirection(d Direction) {
-	opposites := map[Direction]Direction{
-		Up: Down, Down: Up, Left: Right, Right: Left,
+	last := s.Direction
+	if n := len(s.turns); n > 0 {
+		last = s.turns[n-1]
 	}
-	if opposites[d] != s.Direction {
-		s.Direction = d
+	if d == last || d == last.Opposite() || len(s.turns) >= maxQueuedTurns {
+		return
 	}
+	s.turns = append(s.turns, d)
 }
 
 // CollidesWithSelf returns true if the head overlaps any body segment.
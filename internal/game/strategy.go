@@ -0,0 +1,298 @@
+package game
+
+import "fmt"
+
+// Strategy steers a computer-controlled snake. Next is called once per tick
+// before the snakes move and must be deterministic: the same game state
+// always yields the same direction, so bots can be tested headlessly.
+type Strategy interface {
+	Name() string
+	Next(g *Game, p *Player) Direction
+}
+
+// Built-in strategies, from the easiest opponent to the hardest.
+var (
+	// Greedy heads straight for the nearest pod, only looking one step
+	// ahead to avoid dying.
+	Greedy Strategy = greedy{}
+	// ShortestPath follows a breadth-first shortest path to the nearest
+	// pod, routing around walls, decoys and snakes.
+	ShortestPath Strategy = shortestPath{}
+	// Hamiltonian walks a cycle through every cell of the board, which
+	// never traps it. On boards without such a cycle (walls, or odd sides)
+	// it falls back to ShortestPath.
+	Hamiltonian Strategy = hamiltonian{}
+)
+
+// Strategies returns the built-in strategies in menu order.
+func Strategies() []Strategy {
+	return []Strategy{Greedy, ShortestPath, Hamiltonian}
+}
+
+// ParseStrategy looks up a built-in strategy by name.
+func ParseStrategy(s string) (Strategy, error) {
+	for _, st := range Strategies() {
+		if st.Name() == s {
+			return st, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown strategy %q (want greedy, bfs or hamiltonian)", s)
+}
+
+// Opponent levels, each played by the strategy of matching strength.
+var opponentLevels = []struct {
+	level    string
+	strategy Strategy
+}{
+	{"easy", Greedy},
+	{"normal", ShortestPath},
+	{"hard", Hamiltonian},
+}
+
+// ParseOpponent parses the AI opponent setting: off, a level (easy, normal
+// or hard) or a strategy name. Off yields a nil Strategy.
+func ParseOpponent(s string) (Strategy, error) {
+	if s == "off" {
+		return nil, nil
+	}
+	for _, l := range opponentLevels {
+		if l.level == s {
+			return l.strategy, nil
+		}
+	}
+	if st, err := ParseStrategy(s); err == nil {
+		return st, nil
+	}
+	return nil, fmt.Errorf("unknown opponent %q (want off, easy, normal, hard, greedy, bfs or hamiltonian)", s)
+}
+
+// OpponentLevel names the level a strategy plays at, or "" for strategies
+// that are not built in.
+func OpponentLevel(s Strategy) string {
+	for _, l := range opponentLevels {
+		if l.strategy == s {
+			return l.level
+		}
+	}
+	return ""
+}
+
+// directions is the fixed order bots try moves in, so ties are broken the
+// same way every time.
+var directions = []Direction{Up, Right, Down, Left}
+
+// AddBot adds a computer-controlled snake driven by the given strategy.
+func (g *Game) AddBot(name string, s Strategy) (*Player, error) {
+	p, err := g.AddPlayer(name)
+	if err != nil {
+		return nil, err
+	}
+	p.Strategy = s
+	return p, nil
+}
+
+// steerBots lets every living bot pick its next turn.
+func (g *Game) steerBots() {
+	for _, p := range g.Alive() {
+		if p.Strategy != nil {
+			p.Snake.SetDirection(p.Strategy.Next(g, p))
+		}
+	}
+}
+
+// blocked returns every cell a bot must not move into next tick: walls,
+// hazards, snake bodies and decoys. Tails that are about to move away are
+// left open.
+func (g *Game) blocked() map[Position]bool {
+	blocked := make(map[Position]bool)
+	for _, p := range g.Alive() {
+		body := p.Snake.Body
+		if !p.Snake.Growing {
+			body = body[:len(body)-1]
+		}
+		for _, seg := range body {
+			blocked[seg] = true
+		}
+	}
+	for _, pod := range g.Pods {
+		if pod.Protected {
+			blocked[pod.Pos] = true
+		}
+	}
+	return blocked
+}
+
+// open returns true if a bot can safely step onto pos.
+func (g *Game) open(pos Position, blocked map[Position]bool) bool {
+	return !g.Board.IsOutOfBounds(pos) && !g.Board.IsBlocked(pos) && !blocked[pos]
+}
+
+// moves returns the directions the player can take without dying or
+// reversing, in the fixed bot order.
+func (g *Game) moves(p *Player, blocked map[Position]bool) []Direction {
+	var safe []Direction
+	for _, d := range directions {
+		if d == p.Snake.Direction.Opposite() {
+			continue
+		}
+		if g.open(step(p.Snake.Head(), d), blocked) {
+			safe = append(safe, d)
+		}
+	}
+	return safe
+}
+
+// food returns the positions of the pods worth chasing.
+func (g *Game) food() []Position {
+	var food []Position
+	for _, pod := range g.Pods {
+		if !pod.Protected && pod.Phase.Edible() {
+			food = append(food, pod.Pos)
+		}
+	}
+	return food
+}
+
+// roomiest picks the safe move leading to the most reachable cells, the
+// last resort when no pod can be reached. Keeps going straight when boxed
+// in completely.
+func (g *Game) roomiest(p *Player, blocked map[Position]bool) Direction {
+	best, most := p.Snake.Direction, -1
+	for _, d := range g.moves(p, blocked) {
+		if n := g.reachable(step(p.Snake.Head(), d), blocked); n > most {
+			best, most = d, n
+		}
+	}
+	return best
+}
+
+// reachable counts the open cells connected to start.
+func (g *Game) reachable(start Position, blocked map[Position]bool) int {
+	seen := map[Position]bool{start: true}
+	queue := []Position{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, d := range directions {
+			next := step(cur, d)
+			if !seen[next] && g.open(next, blocked) {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return len(seen)
+}
+
+type greedy struct{}
+
+func (greedy) Name() string { return "greedy" }
+
+func (greedy) Next(g *Game, p *Player) Direction {
+	blocked := g.blocked()
+	food := g.food()
+	best, bestDist := Direction(-1), -1
+	for _, d := range g.moves(p, blocked) {
+		next := step(p.Snake.Head(), d)
+		for _, f := range food {
+			if dist := distance(next, f); bestDist < 0 || dist < bestDist {
+				best, bestDist = d, dist
+			}
+		}
+	}
+	if bestDist < 0 {
+		return g.roomiest(p, blocked)
+	}
+	return best
+}
+
+type shortestPath struct{}
+
+func (shortestPath) Name() string { return "bfs" }
+
+func (shortestPath) Next(g *Game, p *Player) Direction {
+	blocked := g.blocked()
+	food := make(map[Position]bool)
+	for _, f := range g.food() {
+		food[f] = true
+	}
+
+	// Breadth-first search remembering the first step of each path.
+	first := make(map[Position]Direction)
+	var queue []Position
+	for _, d := range g.moves(p, blocked) {
+		next := step(p.Snake.Head(), d)
+		first[next] = d
+		queue = append(queue, next)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if food[cur] {
+			return first[cur]
+		}
+		for _, d := range directions {
+			next := step(cur, d)
+			if _, seen := first[next]; !seen && g.open(next, blocked) {
+				first[next] = first[cur]
+				queue = append(queue, next)
+			}
+		}
+	}
+	return g.roomiest(p, blocked)
+}
+
+type hamiltonian struct{}
+
+func (hamiltonian) Name() string { return "hamiltonian" }
+
+func (hamiltonian) Next(g *Game, p *Player) Direction {
+	d, ok := cycleDirection(g.Board, p.Snake.Head())
+	if !ok || len(g.Board.Walls) > 0 {
+		return ShortestPath.Next(g, p)
+	}
+	if d == p.Snake.Direction.Opposite() || !g.open(step(p.Snake.Head(), d), g.blocked()) {
+		// Not on the cycle yet (or someone is in the way): stay alive and
+		// join it further on.
+		return g.roomiest(p, g.blocked())
+	}
+	return d
+}
+
+// cycleDirection returns the direction of a fixed Hamiltonian cycle at pos.
+// The cycle snakes along the rows, leaving column 0 as the way back up; it
+// needs an even number of rows, so boards with an odd height but an even
+// width use the transposed cycle. Returns false if the board has none.
+func cycleDirection(b *Board, pos Position) (Direction, bool) {
+	if b.Width < 2 || b.Height < 2 {
+		return Right, false
+	}
+	if b.Height%2 == 0 {
+		return rowCycle(b.Width, b.Height, pos.X, pos.Y), true
+	}
+	if b.Width%2 == 0 {
+		d := rowCycle(b.Height, b.Width, pos.Y, pos.X)
+		return map[Direction]Direction{Up: Left, Left: Up, Down: Right, Right: Down}[d], true
+	}
+	return Right, false
+}
+
+// rowCycle is cycleDirection on a w x h board with an even h.
+func rowCycle(w, h, x, y int) Direction {
+	switch {
+	case x == 0 && y == 0:
+		return Right
+	case x == 0:
+		return Up
+	case y%2 == 0 && x < w-1:
+		return Right
+	case y%2 == 0:
+		return Down
+	case x > 1:
+		return Left
+	case y == h-1:
+		return Left
+	default:
+		return Down
+	}
+}
@@ -1,5 +1,7 @@
 package game
 
+import "time"
+
 // Direction represents the snake's movement direction.
 type Direction int
 
@@ -10,6 +12,20 @@ const (
 	Right
 )
 
+// Opposite returns the direction pointing the other way.
+func (d Direction) Opposite() Direction {
+	switch d {
+	case Up:
+		return Down
+	case Down:
+		return Up
+	case Left:
+		return Right
+	default:
+		return Left
+	}
+}
+
 // Position represents a coordinate on the game board.
 type Position struct {
 	X int
@@ -21,4 +37,19 @@ type Pod struct {
 	Pos       Position
 	Name      string
 	Namespace string
+	Node      string
+	Phase     PodPhase
+	Protected bool  // a decoy: never eaten, never deleted
+	Restarts  int   // container restarts, makes moving pods faster
+	CPUMillis int64 // CPU requested, makes moving pods faster
+	EatenBy   int   // index of the player that ate or touched the pod
+	Points    int   // scored by the player that ate the pod
+	Born      int   // tick the pod was placed on
+
+	// Cluster metadata, kept so the kill log can show the pod as it was
+	// when it left the board.
+	Labels  map[string]string
+	Owner   string
+	Created time.Time
+	Image   string // first container's image
 }
@@ -0,0 +1,277 @@
+// Package headless runs the game without a terminal UI: a bot plays against
+// the real cluster and every kill is logged as a JSON line. Suited to a
+// CronJob that wants the chaos without anyone watching.
+package headless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kristinb/snakeinak8/internal/game"
+	"github.com/kristinb/snakeinak8/internal/k8s"
+)
+
+const (
+	// Used when Config leaves them zero.
+	defaultBoardWidth   = 40
+	defaultBoardHeight  = 20
+	defaultFetchTimeout = 5 * time.Second
+
+	// emptyBackoff is how many ticks to wait after finding no pods to eat.
+	emptyBackoff = 20
+	// watchBackoff is how many ticks to wait before watching pods again
+	// after a watch failed to open.
+	watchBackoff = 20
+)
+
+// Config controls a headless run.
+type Config struct {
+	Duration   time.Duration // how long to play, 0 to play until cancelled
+	Strategy   game.Strategy // steers the snake
+	Difficulty game.Difficulty
+	KillRate   int // most pods killed per minute, 0 for no cap
+	Width      int // board size in cells, 0 for the default
+	Height     int
+	// FetchTimeout bounds every API call made from the loop, 0 for the
+	// default.
+	FetchTimeout time.Duration
+}
+
+// Summary is what a run did, logged when it ends.
+type Summary struct {
+	Games   int
+	Kills   int
+	Spared  int // eaten over the kill-rate cap, left running
+	Failed  int
+	Best    int // highest score of any game
+	Elapsed time.Duration
+}
+
+// Run plays games back to back until the duration is up or ctx is
+// cancelled, killing the pods the bot eats. Pods come from the same
+// client.RandomPod and go through the same client.KillPod guards as in the
+// interactive game, and kills are capped by the same KillLimiter.
+func Run(ctx context.Context, client *k8s.Client, cfg Config, log *slog.Logger) Summary {
+	start := time.Now()
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	if cfg.Width == 0 || cfg.Height == 0 {
+		cfg.Width, cfg.Height = defaultBoardWidth, defaultBoardHeight
+	}
+	if cfg.FetchTimeout == 0 {
+		cfg.FetchTimeout = defaultFetchTimeout
+	}
+	r := runner{
+		client:  client,
+		cfg:     cfg,
+		log:     log,
+		limiter: k8s.NewKillLimiter(cfg.KillRate),
+	}
+	log.Info("start",
+		"cluster", client.ClusterName(),
+		"namespace", client.Namespace(),
+		"strategy", cfg.Strategy.Name(),
+		"duration", cfg.Duration.String(),
+		"kill_rate", cfg.KillRate,
+		"board", fmt.Sprintf("%dx%d", cfg.Width, cfg.Height),
+	)
+
+	for ctx.Err() == nil {
+		r.play(ctx)
+	}
+
+	r.summary.Elapsed = time.Since(start).Round(time.Second)
+	log.Info("summary",
+		"games", r.summary.Games,
+		"kills", r.summary.Kills,
+		"spared", r.summary.Spared,
+		"failed", r.summary.Failed,
+		"best_score", r.summary.Best,
+		"elapsed", r.summary.Elapsed.String(),
+	)
+	return r.summary
+}
+
+// runner carries the state shared by the games of one run.
+type runner struct {
+	client  *k8s.Client
+	cfg     Config
+	log     *slog.Logger
+	limiter *k8s.KillLimiter
+	summary Summary
+}
+
+// play runs a single game until it ends or ctx is done.
+func (r *runner) play(ctx context.Context) {
+	g := game.New(r.cfg.Width, r.cfg.Height)
+	g.SetDifficulty(r.cfg.Difficulty)
+	g.Players[0].Name = "autopilot"
+	g.Players[0].Strategy = r.cfg.Strategy
+	r.summary.Games++
+
+	known := make(map[string]bool)
+	wait, rewatch := 0, 0
+	ticker := time.NewTicker(g.TickRate())
+	defer ticker.Stop()
+
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	defer stopWatch()
+	events := r.watch(watchCtx, g, known)
+	if events == nil {
+		rewatch = watchBackoff
+	}
+
+	for g.State == game.StateRunning {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-events:
+			if ok {
+				r.applyPodEvent(g, known, ev)
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			// The server ended the watch; pick up where it left off.
+			if events = r.watch(watchCtx, g, known); events == nil {
+				rewatch = watchBackoff
+			}
+			continue
+		case <-ticker.C:
+		}
+
+		if events == nil {
+			if rewatch > 0 {
+				rewatch--
+			} else if events = r.watch(watchCtx, g, known); events == nil {
+				rewatch = watchBackoff
+			}
+		}
+
+		for _, pod := range g.Tick() {
+			delete(known, pod.Name)
+			r.kill(ctx, g, pod)
+		}
+		ticker.Reset(g.TickRate())
+
+		budget := r.limiter.Remaining(time.Now())
+		if wait > 0 {
+			wait--
+		} else if g.EdibleCount() < g.MaxPods && (budget < 0 || g.EdibleCount() < budget) {
+			if !r.feed(ctx, g, known) {
+				wait = emptyBackoff
+			}
+		}
+	}
+
+	r.summary.Best = max(r.summary.Best, g.Score)
+	r.log.Info("game_over", "score", g.Score, "kills", g.KillCount, "ticks", g.Ticks)
+}
+
+// feed puts one more pod on the board. Returns false if none was found.
+func (r *runner) feed(ctx context.Context, g *game.Game, known map[string]bool) bool {
+	fetchCtx, cancel := context.WithTimeout(ctx, r.cfg.FetchTimeout)
+	defer cancel()
+	pod, err := r.client.RandomPod(fetchCtx, known)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Warn("fetch_failed", "error", err.Error())
+		}
+		return false
+	}
+	if pod == nil {
+		return false
+	}
+	phase, _ := game.ParsePodPhase(pod.Phase)
+	if g.AddPod(game.Pod{
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		Node:      pod.NodeName,
+		Phase:     phase,
+		Restarts:  pod.Restarts,
+		CPUMillis: pod.CPUMillis,
+	}) {
+		known[pod.Name] = true
+	}
+	return true
+}
+
+// watch opens a pod watch and catches the board up with the pods it lists:
+// pods on the board missing from the list were deleted while nobody was
+// watching. Returns nil if the watch could not be opened.
+func (r *runner) watch(ctx context.Context, g *game.Game, known map[string]bool) <-chan k8s.PodEvent {
+	pods, events, err := r.client.WatchPods(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Warn("watch_failed", "error", err.Error())
+		}
+		return nil
+	}
+	present := make(map[string]bool, len(pods))
+	for _, ev := range pods {
+		present[ev.Namespace+"/"+ev.Name] = true
+		r.applyPodEvent(g, known, ev)
+	}
+	for _, pod := range slices.Clone(g.Pods) {
+		if !present[pod.Namespace+"/"+pod.Name] {
+			r.applyPodEvent(g, known, k8s.PodEvent{Name: pod.Name, Namespace: pod.Namespace, Deleted: true})
+		}
+	}
+	return events
+}
+
+// applyPodEvent follows a pod on the board through a phase change, or lets
+// it evaporate when it was deleted or stopped being shown, as in the
+// interactive game.
+func (r *runner) applyPodEvent(g *game.Game, known map[string]bool, ev k8s.PodEvent) {
+	phase, shown := game.ParsePodPhase(ev.Phase)
+	if !ev.Deleted && shown && (r.client.AllPhases() || phase == game.PhaseRunning) {
+		g.SetPodPhase(ev.Name, ev.Namespace, phase)
+		return
+	}
+	if pod, ok := g.RemovePod(ev.Name, ev.Namespace); ok {
+		reason := "deleted"
+		if !ev.Deleted {
+			reason = strings.ToLower(ev.Phase)
+		}
+		delete(known, pod.Name)
+		r.log.Info("evaporated", "pod", pod.Name, "namespace", pod.Namespace, "reason", reason)
+	}
+}
+
+// kill deletes an eaten pod unless the kill-rate cap says otherwise, in
+// which case the pod scores nothing.
+func (r *runner) kill(ctx context.Context, g *game.Game, pod game.Pod) {
+	if !r.limiter.Allow(time.Now()) {
+		g.Spare(pod)
+		r.summary.Spared++
+		r.log.Info("spared", "pod", pod.Name, "namespace", pod.Namespace, "reason", "kill-rate cap")
+		return
+	}
+
+	killCtx, cancel := context.WithTimeout(ctx, r.cfg.FetchTimeout)
+	defer cancel()
+	if err := r.client.KillPod(killCtx, pod.Name, pod.Namespace); err != nil {
+		r.summary.Failed++
+		level := slog.LevelWarn
+		if errors.Is(err, k8s.ErrNotFood) {
+			level = slog.LevelError
+		}
+		r.log.Log(ctx, level, "kill_failed", "pod", pod.Name, "namespace", pod.Namespace, "error", err.Error())
+		return
+	}
+	r.summary.Kills++
+	// Best-effort, like in the interactive game.
+	_ = r.client.RecordKill(killCtx, pod.Name, pod.Namespace, "autopilot")
+	r.log.Info("kill", "pod", pod.Name, "namespace", pod.Namespace, "node", pod.Node)
+}
@@ -0,0 +1,188 @@
+package headless
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kristinb/snakeinak8/internal/game"
+	"github.com/kristinb/snakeinak8/internal/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func foodPod(name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "snakefood",
+			Labels:    map[string]string{"app": "snakefood"},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func newRunner(killRate int, pods ...runtime.Object) (*runner, *fake.Clientset) {
+	cs := fake.NewClientset(pods...)
+	client := k8s.NewClientFromInterfaces(cs, nil, "test", "")
+	client.SetAllPhases(true)
+	return &runner{
+		client: client,
+		cfg: Config{
+			Strategy:     game.ShortestPath,
+			Difficulty:   game.Normal,
+			KillRate:     killRate,
+			Width:        20,
+			Height:       10,
+			FetchTimeout: time.Second,
+		},
+		log:     slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		limiter: k8s.NewKillLimiter(killRate),
+	}, cs
+}
+
+func TestFeedKeepsThePodPhase(t *testing.T) {
+	terminating := foodPod("leaving", corev1.PodRunning)
+	now := metav1.Now()
+	terminating.DeletionTimestamp = &now
+	terminating.Finalizers = []string{"example.com/hold"}
+	r, _ := newRunner(0, terminating)
+
+	g := game.New(20, 10)
+	known := make(map[string]bool)
+	if !r.feed(context.Background(), g, known) {
+		t.Fatal("expected a pod to be found")
+	}
+	if len(g.Pods) != 1 || g.Pods[0].Phase != game.PhaseTerminating {
+		t.Fatalf("expected a terminating pod on the board, got %+v", g.Pods)
+	}
+	if g.Pods[0].Phase.Edible() {
+		t.Fatal("terminating pods must not be edible")
+	}
+	if !known["leaving"] {
+		t.Fatal("expected the placed pod to be remembered")
+	}
+}
+
+func TestFeedOnlyRemembersPlacedPods(t *testing.T) {
+	r, _ := newRunner(0, foodPod("web", corev1.PodRunning))
+
+	g := game.New(20, 10)
+	g.MaxPods = 0 // the board takes no more food
+	known := make(map[string]bool)
+	r.feed(context.Background(), g, known)
+	if len(g.Pods) != 0 || known["web"] {
+		t.Fatalf("expected nothing placed or remembered, got %+v and %v", g.Pods, known)
+	}
+}
+
+func TestKillDeletesFood(t *testing.T) {
+	r, cs := newRunner(0, foodPod("web", corev1.PodRunning))
+	g := game.New(20, 10)
+
+	r.kill(context.Background(), g, game.Pod{Name: "web", Namespace: "snakefood"})
+	if r.summary.Kills != 1 || r.summary.Failed != 0 {
+		t.Fatalf("expected one kill, got %+v", r.summary)
+	}
+	if _, err := cs.CoreV1().Pods("snakefood").Get(context.Background(), "web", metav1.GetOptions{}); err == nil {
+		t.Fatal("expected the pod to be deleted")
+	}
+}
+
+func TestKillRefusesProtectedPods(t *testing.T) {
+	pod := foodPod("db", corev1.PodRunning)
+	pod.Labels[k8s.ProtectedLabel] = "true"
+	r, cs := newRunner(0, pod)
+	g := game.New(20, 10)
+
+	r.kill(context.Background(), g, game.Pod{Name: "db", Namespace: "snakefood"})
+	if r.summary.Kills != 0 || r.summary.Failed != 1 {
+		t.Fatalf("expected one failed kill, got %+v", r.summary)
+	}
+	if _, err := cs.CoreV1().Pods("snakefood").Get(context.Background(), "db", metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected the protected pod to survive, got %v", err)
+	}
+}
+
+func TestKillSparesOverTheCap(t *testing.T) {
+	r, cs := newRunner(1, foodPod("a", corev1.PodRunning), foodPod("b", corev1.PodRunning))
+	g := game.New(20, 10)
+	g.Score, g.KillCount = 2, 2
+	g.Players[0].Score, g.Players[0].KillCount = 2, 2
+
+	r.kill(context.Background(), g, game.Pod{Name: "a", Namespace: "snakefood", Points: 1})
+	r.kill(context.Background(), g, game.Pod{Name: "b", Namespace: "snakefood", Points: 1})
+	if r.summary.Kills != 1 || r.summary.Spared != 1 {
+		t.Fatalf("expected one kill and one spared, got %+v", r.summary)
+	}
+	if _, err := cs.CoreV1().Pods("snakefood").Get(context.Background(), "b", metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected the spared pod to survive, got %v", err)
+	}
+	if g.Score != 1 || g.KillCount != 1 {
+		t.Fatalf("expected the spared pod to score nothing, got score %d kills %d", g.Score, g.KillCount)
+	}
+}
+
+func TestWatchRemovesPodsThatDie(t *testing.T) {
+	r, cs := newRunner(0, foodPod("web", corev1.PodRunning), foodPod("api", corev1.PodRunning))
+	g := game.New(20, 10)
+	for _, name := range []string{"web", "api", "gone"} {
+		g.AddPod(game.Pod{Name: name, Namespace: "snakefood"})
+	}
+	known := map[string]bool{"web": true, "api": true, "gone": true}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := r.watch(ctx, g, known)
+	if events == nil {
+		t.Fatal("expected the watch to open")
+	}
+	if len(g.Pods) != 2 || known["gone"] {
+		t.Fatalf("expected the pod missing from the cluster to leave the board, got %+v", g.Pods)
+	}
+
+	if err := cs.CoreV1().Pods("snakefood").Delete(ctx, "web", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case ev := <-events:
+		r.applyPodEvent(g, known, ev)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected an event for the deleted pod")
+	}
+	if len(g.Pods) != 1 || g.Pods[0].Name != "api" || known["web"] {
+		t.Fatalf("expected the deleted pod to leave the board, got %+v", g.Pods)
+	}
+}
+
+func TestRunStopsAfterTheDuration(t *testing.T) {
+	client := k8s.NewClientFromInterfaces(fake.NewClientset(foodPod("web", corev1.PodRunning)), nil, "test", "")
+	var out bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&out, nil))
+
+	start := time.Now()
+	summary := Run(context.Background(), client, Config{
+		Duration:   300 * time.Millisecond,
+		Strategy:   game.ShortestPath,
+		Difficulty: game.Normal,
+		Width:      30,
+		Height:     15,
+	}, log)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the run to stop after its duration, took %s", elapsed)
+	}
+	if summary.Games < 1 {
+		t.Fatalf("expected at least one game, got %+v", summary)
+	}
+	for _, msg := range []string{`"msg":"start"`, `"board":"30x15"`, `"msg":"summary"`} {
+		if !strings.Contains(out.String(), msg) {
+			t.Errorf("expected %s in the log, got:\n%s", msg, out.String())
+		}
+	}
+}
@@ -2,29 +2,97 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
 
-// PodInfo holds the minimal info we need from a running pod.
+// ProtectedLabel marks pods that must never be deleted. They appear on the
+// board as decoys instead of food.
+const ProtectedLabel = "snakeinak8.io/protected"
+
+// Label selectors for edible pods and decoys. Protected pods are excluded
+// from food even if they also carry app=snakefood.
+const (
+	FoodSelector      = "app=snakefood," + ProtectedLabel + "!=true"
+	ProtectedSelector = ProtectedLabel + "=true"
+)
+
+// Pod phases as reported to the game. Besides the API phases, crash-looping
+// and terminating pods get a phase of their own.
+const (
+	PhaseRunning     = "Running"
+	PhasePending     = "Pending"
+	PhaseCrashLoop   = "CrashLoopBackOff"
+	PhaseTerminating = "Terminating"
+	PhaseSucceeded   = "Succeeded"
+	PhaseFailed      = "Failed"
+)
+
+// PodInfo holds the minimal info we need from a pod.
 type PodInfo struct {
 	Name      string
 	Namespace string
+	NodeName  string
+	Phase     string
+	Protected bool
+	Restarts  int   // summed over all containers
+	CPUMillis int64 // CPU requests summed over all containers
+	Labels    map[string]string
+	Owner     string // controlling owner as Kind/name, empty for bare pods
+	Created   time.Time
+	Image     string // first container's image
+}
+
+// NodeInfo holds the minimal info we need from a cluster node.
+type NodeInfo struct {
+	Name          string
+	Ready         bool
+	Unschedulable bool // cordoned
+}
+
+// Resource kinds that can appear on the board as power-ups.
+const (
+	KindConfigMap = "ConfigMap"
+	KindService   = "Service"
+	KindSecret    = "Secret"
+	KindPVC       = "PersistentVolumeClaim"
+)
+
+// PowerUpKinds lists the resource kinds RandomResource understands.
+var PowerUpKinds = []string{KindConfigMap, KindService, KindSecret, KindPVC}
+
+// ResourceInfo identifies a non-pod resource shown on the board.
+type ResourceInfo struct {
+	Kind      string
+	Name      string
+	Namespace string
 }
 
 // Client wraps the Kubernetes clientset for pod operations.
 type Client struct {
-	clientset   *kubernetes.Clientset
+	clientset   kubernetes.Interface
+	metadata    metadata.Interface // lists power-ups without their contents
 	rawConfig   api.Config
 	clusterName string
 	namespace   string // empty string means all namespaces
+	allPhases   bool   // also pick Pending, crash-looping and terminating pods
 }
 
 // ResolveKubeconfig returns the kubeconfig path by checking, in order:
@@ -42,9 +110,15 @@ func ResolveKubeconfig(flagValue string) string {
 	return filepath.Join(home, ".kube", "config")
 }
 
-// NewClient builds a Client from the given kubeconfig path.
+// NewClient builds a Client from the given kubeconfig path. When that file
+// does not exist and we run inside a pod (a CronJob, say), the pod's service
+// account is used instead.
 // Pass an empty namespace to operate across all namespaces.
 func NewClient(kubeconfigPath, namespace string) (*Client, error) {
+	if _, err := os.Stat(kubeconfigPath); err != nil && os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return newInClusterClient(namespace)
+	}
+
 	loadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath}
 	configOverrides := &clientcmd.ConfigOverrides{}
 	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
@@ -63,18 +137,53 @@ func NewClient(kubeconfigPath, namespace string) (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create clientset: %w", err)
 	}
+	md, err := metadata.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata client: %w", err)
+	}
 
 	clusterName := rawConfig.CurrentContext
 	if ctx, ok := rawConfig.Contexts[rawConfig.CurrentContext]; ok && ctx.Cluster != "" {
 		clusterName = ctx.Cluster
 	}
 
+	c := NewClientFromInterfaces(cs, md, clusterName, namespace)
+	c.rawConfig = rawConfig
+	return c, nil
+}
+
+// newInClusterClient builds a Client from the pod's service account.
+func newInClusterClient(namespace string) (*Client, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build in-cluster config: %w", err)
+	}
+	cs, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+	md, err := metadata.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata client: %w", err)
+	}
+	return NewClientFromInterfaces(cs, md, "in-cluster", namespace), nil
+}
+
+// NewClientFromInterfaces builds a Client around existing clients, such as
+// the fakes in client-go's fake packages.
+func NewClientFromInterfaces(cs kubernetes.Interface, md metadata.Interface, clusterName, namespace string) *Client {
 	return &Client{
 		clientset:   cs,
-		rawConfig:   rawConfig,
+		metadata:    md,
 		clusterName: clusterName,
 		namespace:   namespace,
-	}, nil
+	}
+}
+
+// Clientset returns the underlying clientset, for packages that keep their
+// own state in the cluster (the shared leaderboard).
+func (c *Client) Clientset() kubernetes.Interface {
+	return c.clientset
 }
 
 // ClusterName returns the name of the current cluster context.
@@ -92,6 +201,25 @@ func (c *Client) SetNamespace(ns string) {
 	c.namespace = ns
 }
 
+// SetAllPhases controls whether pods in phases other than Running are
+// picked. Finished pods are never picked.
+func (c *Client) SetAllPhases(all bool) {
+	c.allPhases = all
+}
+
+// AllPhases reports whether pods in phases other than Running are picked.
+func (c *Client) AllPhases() bool {
+	return c.allPhases
+}
+
+// phaseSelector returns the field selector for the pods the game may show.
+func (c *Client) phaseSelector() string {
+	if c.allPhases {
+		return "status.phase!=" + PhaseSucceeded + ",status.phase!=" + PhaseFailed
+	}
+	return "status.phase=" + PhaseRunning
+}
+
 // ListNamespaces returns all namespace names in the cluster.
 func (c *Client) ListNamespaces(ctx context.Context) ([]string, error) {
 	nsList, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
@@ -105,16 +233,75 @@ func (c *Client) ListNamespaces(ctx context.Context) ([]string, error) {
 	return names, nil
 }
 
+// ListNodes returns every node in the cluster, sorted by name, with its
+// readiness and cordon state.
+func (c *Client) ListNodes(ctx context.Context) ([]NodeInfo, error) {
+	nodeList, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	nodes := make([]NodeInfo, 0, len(nodeList.Items))
+	for _, n := range nodeList.Items {
+		info := NodeInfo{Name: n.Name, Unschedulable: n.Spec.Unschedulable}
+		for _, cond := range n.Status.Conditions {
+			if cond.Type == corev1.NodeReady {
+				info.Ready = cond.Status == corev1.ConditionTrue
+			}
+		}
+		nodes = append(nodes, info)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes, nil
+}
+
+// PodNamespaces returns the sorted names of namespaces that currently hold
+// snakefood pods the game may show, honouring the client's namespace filter.
+func (c *Client) PodNamespaces(ctx context.Context) ([]string, error) {
+	pods, err := c.clientset.CoreV1().Pods(c.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: FoodSelector,
+		FieldSelector: c.phaseSelector(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, p := range pods.Items {
+		if !seen[p.Namespace] {
+			seen[p.Namespace] = true
+			names = append(names, p.Namespace)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // RandomPod picks a random running pod, filtered by the client's namespace.
-// If namespace is empty, picks from all namespaces.
-// Only picks pods with the label app=snakefood to avoid killing real workloads.
+// If namespace is empty, picks from all namespaces. With SetAllPhases, any
+// unfinished pod may be picked.
+// Only picks pods with the label app=snakefood to avoid killing real workloads,
+// and never pods labelled as protected.
 // Pods whose names appear in exclude are skipped.
 func (c *Client) RandomPod(ctx context.Context, exclude map[string]bool) (*PodInfo, error) {
+	return c.randomPod(ctx, FoodSelector, exclude)
+}
+
+// RandomProtectedPod picks a random running pod labelled as protected, to be
+// shown as a decoy. The pod is only read, never deleted.
+func (c *Client) RandomProtectedPod(ctx context.Context, exclude map[string]bool) (*PodInfo, error) {
+	pod, err := c.randomPod(ctx, ProtectedSelector, exclude)
+	if pod != nil {
+		pod.Protected = true
+	}
+	return pod, err
+}
+
+func (c *Client) randomPod(ctx context.Context, selector string, exclude map[string]bool) (*PodInfo, error) {
 	ns := c.namespace // empty string = all namespaces in the API
 
 	pods, err := c.clientset.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{
-		LabelSelector: "app=snakefood",
-		FieldSelector: "status.phase=Running",
+		LabelSelector: selector,
+		FieldSelector: c.phaseSelector(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods: %w", err)
@@ -124,7 +311,7 @@ func (c *Client) RandomPod(ctx context.Context, exclude map[string]bool) (*PodIn
 	var candidates []PodInfo
 	for _, p := range pods.Items {
 		if !exclude[p.Name] {
-			candidates = append(candidates, PodInfo{Name: p.Name, Namespace: p.Namespace})
+			candidates = append(candidates, podInfo(p))
 		}
 	}
 
@@ -136,14 +323,225 @@ func (c *Client) RandomPod(ctx context.Context, exclude map[string]bool) (*PodIn
 	return &pick, nil
 }
 
-// KillPod force-deletes the given pod. Brutal.
+// podInfo extracts the fields the game cares about from a pod.
+func podInfo(p corev1.Pod) PodInfo {
+	info := PodInfo{
+		Name:      p.Name,
+		Namespace: p.Namespace,
+		NodeName:  p.Spec.NodeName,
+		Phase:     podPhase(p),
+		Labels:    p.Labels,
+		Created:   p.CreationTimestamp.Time,
+	}
+	if owner := metav1.GetControllerOf(&p); owner != nil {
+		info.Owner = owner.Kind + "/" + owner.Name
+	}
+	for _, cs := range p.Status.ContainerStatuses {
+		info.Restarts += int(cs.RestartCount)
+	}
+	if len(p.Spec.Containers) > 0 {
+		info.Image = p.Spec.Containers[0].Image
+	}
+	for _, c := range p.Spec.Containers {
+		info.CPUMillis += c.Resources.Requests.Cpu().MilliValue()
+	}
+	return info
+}
+
+// podPhase returns the pod's phase, refined for terminating and
+// crash-looping pods.
+func podPhase(p corev1.Pod) string {
+	if p.DeletionTimestamp != nil {
+		return PhaseTerminating
+	}
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason == PhaseCrashLoop {
+			return PhaseCrashLoop
+		}
+	}
+	return string(p.Status.Phase)
+}
+
+// PodEvent reports that a pod the game may be showing was deleted or
+// changed phase.
+type PodEvent struct {
+	Name      string
+	Namespace string
+	Phase     string
+	Deleted   bool
+}
+
+// WatchPods lists the edible and protected pods in the client's namespace,
+// then streams PodEvents for what changes after that list until ctx is
+// cancelled or the server ends the watch. The channel is then closed and
+// the caller should watch again. The list tells a caller that watches again
+// which pods went away while it was not watching: any it shows that are
+// missing from the list.
+func (c *Client) WatchPods(ctx context.Context) ([]PodEvent, <-chan PodEvent, error) {
+	var pods []PodEvent
+	var watchers []watch.Interface
+	stop := func() {
+		for _, w := range watchers {
+			w.Stop()
+		}
+	}
+	for _, selector := range []string{FoodSelector, ProtectedSelector} {
+		list, err := c.clientset.CoreV1().Pods(c.namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector,
+		})
+		if err != nil {
+			stop()
+			return nil, nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		for _, p := range list.Items {
+			pods = append(pods, PodEvent{Name: p.Name, Namespace: p.Namespace, Phase: podPhase(p)})
+		}
+		w, err := c.clientset.CoreV1().Pods(c.namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector:   selector,
+			ResourceVersion: list.ResourceVersion,
+		})
+		if err != nil {
+			stop()
+			return nil, nil, fmt.Errorf("failed to watch pods: %w", err)
+		}
+		watchers = append(watchers, w)
+	}
+
+	out := make(chan PodEvent)
+	var wg sync.WaitGroup
+	for _, w := range watchers {
+		wg.Add(1)
+		go func(w watch.Interface) {
+			defer wg.Done()
+			defer w.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case ev, ok := <-w.ResultChan():
+					if !ok {
+						return
+					}
+					pe, ok := podEvent(ev)
+					if !ok {
+						continue
+					}
+					select {
+					case out <- pe:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}(w)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return pods, out, nil
+}
+
+// podEvent translates a watch event into a PodEvent. Only deletions and
+// modifications are reported.
+func podEvent(ev watch.Event) (PodEvent, bool) {
+	p, ok := ev.Object.(*corev1.Pod)
+	if !ok || (ev.Type != watch.Deleted && ev.Type != watch.Modified) {
+		return PodEvent{}, false
+	}
+	return PodEvent{
+		Name:      p.Name,
+		Namespace: p.Namespace,
+		Phase:     podPhase(*p),
+		Deleted:   ev.Type == watch.Deleted,
+	}, true
+}
+
+// resourceKinds maps the power-up kinds to their API resources.
+var resourceKinds = map[string]schema.GroupVersionResource{
+	KindConfigMap: corev1.SchemeGroupVersion.WithResource("configmaps"),
+	KindService:   corev1.SchemeGroupVersion.WithResource("services"),
+	KindSecret:    corev1.SchemeGroupVersion.WithResource("secrets"),
+	KindPVC:       corev1.SchemeGroupVersion.WithResource("persistentvolumeclaims"),
+}
+
+// RandomResource picks a random resource of the given kind, filtered by the
+// client's namespace. Resources are listed through the metadata API, so no
+// Secret data ever leaves the cluster, and are never modified. Returns nil
+// if none exist.
+func (c *Client) RandomResource(ctx context.Context, kind string) (*ResourceInfo, error) {
+	gvr, ok := resourceKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported resource kind %q", kind)
+	}
+	list, err := c.metadata.Resource(gvr).Namespace(c.namespace).List(ctx, metav1.ListOptions{Limit: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+	o := list.Items[rand.Intn(len(list.Items))]
+	return &ResourceInfo{Kind: kind, Name: o.Name, Namespace: o.Namespace}, nil
+}
+
+// ErrNotFood is returned by KillPod for pods that do not match
+// FoodSelector, protected pods included.
+var ErrNotFood = errors.New("pod is not snakefood")
+
+// KillPod force-deletes the given pod. Brutal. As a last guard, shared by
+// every mode that kills pods, the pod is looked up first and left alone
+// unless its labels still match FoodSelector.
 func (c *Client) KillPod(ctx context.Context, name, namespace string) error {
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to kill pod %s/%s: %w", namespace, name, err)
+	}
+	food, err := labels.Parse(FoodSelector)
+	if err != nil {
+		return fmt.Errorf("failed to parse food selector: %w", err)
+	}
+	if !food.Matches(labels.Set(pod.Labels)) {
+		return fmt.Errorf("refusing to kill pod %s/%s: %w", namespace, name, ErrNotFood)
+	}
+
 	gracePeriod := int64(0)
-	err := c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{
+	err = c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{
 		GracePeriodSeconds: &gracePeriod,
+		Preconditions:      &metav1.Preconditions{UID: &pod.UID},
 	})
 	if err != nil {
 		return fmt.Errorf("failed to kill pod %s/%s: %w", namespace, name, err)
 	}
 	return nil
 }
+
+// RecordKill posts a Kubernetes Event on the killed pod crediting the player
+// that ate it, so `kubectl get events` tells who did it.
+func (c *Client) RecordKill(ctx context.Context, name, namespace, by string) error {
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name + ".",
+			Namespace:    namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:       "Pod",
+			APIVersion: "v1",
+			Name:       name,
+			Namespace:  namespace,
+		},
+		Reason:         "Eaten",
+		Message:        fmt.Sprintf("Pod eaten by %s in snakeinak8", by),
+		Type:           corev1.EventTypeNormal,
+		Source:         corev1.EventSource{Component: "snakeinak8"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	_, err := c.clientset.CoreV1().Events(namespace).Create(ctx, event, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to record kill of %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
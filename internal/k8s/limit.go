@@ -0,0 +1,60 @@
+package k8s
+
+import (
+	"sync"
+	"time"
+)
+
+// KillLimiter caps how many pods may be deleted per minute when nobody is at
+// the keyboard. A nil limiter allows everything.
+type KillLimiter struct {
+	perMinute int
+	mu        sync.Mutex
+	kills     []time.Time // kills within the last minute, oldest first
+}
+
+// NewKillLimiter returns a limiter allowing perMinute kills in any sliding
+// minute. Returns nil (no cap) if perMinute is zero or less.
+func NewKillLimiter(perMinute int) *KillLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &KillLimiter{perMinute: perMinute}
+}
+
+// Allow reports whether a kill may happen at now, and records it if so.
+func (l *KillLimiter) Allow(now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire(now)
+	if len(l.kills) >= l.perMinute {
+		return false
+	}
+	l.kills = append(l.kills, now)
+	return true
+}
+
+// Remaining returns how many more kills are allowed right now, or -1 when
+// there is no cap.
+func (l *KillLimiter) Remaining(now time.Time) int {
+	if l == nil {
+		return -1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire(now)
+	return l.perMinute - len(l.kills)
+}
+
+// expire forgets kills older than a minute.
+func (l *KillLimiter) expire(now time.Time) {
+	cutoff := now.Add(-time.Minute)
+	i := 0
+	for i < len(l.kills) && !l.kills[i].After(cutoff) {
+		i++
+	}
+	l.kills = l.kills[i:]
+}
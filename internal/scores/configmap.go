@@ -0,0 +1,135 @@
+package scores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// ConfigMapName is the ConfigMap holding a cluster's leaderboard.
+	ConfigMapName = "snakeinak8-leaderboard"
+	// configMapKey is the data key the tables are stored under.
+	configMapKey = "scores.json"
+)
+
+// ConfigMap stores the tables in a ConfigMap, so everyone playing on the
+// cluster shares one leaderboard. Writes are read-modify-write cycles
+// guarded by the ConfigMap's resourceVersion: a concurrent update makes
+// ours fail with a conflict, and we retry on top of the newer data.
+//
+// The cluster is implied by where the ConfigMap lives, so tables are keyed
+// by namespace and mode only: players whose kubeconfigs name the cluster
+// differently still land on the same board.
+type ConfigMap struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewConfigMap returns a store backed by the ConfigMapName ConfigMap in
+// namespace. The ConfigMap is created on the first score.
+func NewConfigMap(client kubernetes.Interface, namespace string) *ConfigMap {
+	return &ConfigMap{client: client, namespace: namespace, name: ConfigMapName}
+}
+
+// tableKey drops the cluster from key.
+func tableKey(key Key) string {
+	key.Cluster = ""
+	return key.String()
+}
+
+// Top returns the table for key, best first.
+func (c *ConfigMap) Top(ctx context.Context, key Key) ([]Entry, error) {
+	tables, _, err := c.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tables[tableKey(key)], nil
+}
+
+// Players ranks everyone on the leaderboard. The cluster is ignored: the
+// ConfigMap only ever holds one.
+func (c *ConfigMap) Players(ctx context.Context, _ string) ([]Standing, error) {
+	tables, _, err := c.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return standings(tables, ""), nil
+}
+
+// Add records an entry in the table for key, retrying when another player
+// saved at the same time.
+func (c *ConfigMap) Add(ctx context.Context, key Key, e Entry) (int, error) {
+	var rank int
+	retriable := func(err error) bool {
+		// Two first scores may race to create the ConfigMap.
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}
+	err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+		tables, cm, err := c.read(ctx)
+		if err != nil {
+			return err
+		}
+		var table []Entry
+		table, rank = insert(tables[tableKey(key)], e)
+		if rank == 0 {
+			return nil
+		}
+		tables[tableKey(key)] = table
+		data, err := json.Marshal(tables)
+		if err != nil {
+			return fmt.Errorf("failed to encode scores: %w", err)
+		}
+
+		if cm == nil {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      c.name,
+					Namespace: c.namespace,
+					Labels:    map[string]string{"app.kubernetes.io/name": "snakeinak8"},
+				},
+				Data: map[string]string{configMapKey: string(data)},
+			}
+			_, err = c.client.CoreV1().ConfigMaps(c.namespace).Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		}
+		// cm still carries the resourceVersion we read, so the update
+		// fails with a conflict if anyone wrote in between.
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[configMapKey] = string(data)
+		_, err = c.client.CoreV1().ConfigMaps(c.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to save score to %s/%s: %w", c.namespace, c.name, err)
+	}
+	return rank, nil
+}
+
+// read fetches the tables and the ConfigMap holding them, which is nil if
+// it does not exist yet.
+func (c *ConfigMap) read(ctx context.Context) (Tables, *corev1.ConfigMap, error) {
+	cm, err := c.client.CoreV1().ConfigMaps(c.namespace).Get(ctx, c.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return Tables{}, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read leaderboard %s/%s: %w", c.namespace, c.name, err)
+	}
+	tables := Tables{}
+	if data := cm.Data[configMapKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &tables); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse leaderboard %s/%s: %w", c.namespace, c.name, err)
+		}
+	}
+	return tables, cm, nil
+}
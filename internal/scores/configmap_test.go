@@ -0,0 +1,91 @@
+package scores
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestConfigMapSharesTablesAcrossClusterNames(t *testing.T) {
+	ctx := context.Background()
+	cm := NewConfigMap(fake.NewClientset(), "snakefood")
+
+	if rank, err := cm.Add(ctx, Key{Cluster: "kind-dev", Mode: "classic"}, Entry{Name: "ann", Score: 3}); err != nil || rank != 1 {
+		t.Fatalf("expected rank 1, got %d (%v)", rank, err)
+	}
+	if rank, err := cm.Add(ctx, Key{Cluster: "dev", Mode: "classic"}, Entry{Name: "bob", Score: 5}); err != nil || rank != 1 {
+		t.Fatalf("expected rank 1, got %d (%v)", rank, err)
+	}
+
+	top, err := cm.Top(ctx, Key{Cluster: "anything", Mode: "classic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0].Name != "bob" {
+		t.Fatalf("expected bob then ann, got %+v", top)
+	}
+
+	players, err := cm.Players(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 2 || players[0].Name != "bob" || players[0].Best != 5 {
+		t.Fatalf("expected bob to lead, got %+v", players)
+	}
+}
+
+func TestConfigMapRetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	cm := NewConfigMap(client, "snakefood")
+	key := Key{Mode: "classic"}
+	if _, err := cm.Add(ctx, key, Entry{Name: "ann", Score: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first update loses a race: another terminal saves its score
+	// first, and ours is rejected with a stale resourceVersion. The other
+	// write goes straight to the tracker, since reactors run under the
+	// fake client's lock.
+	raced := false
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	client.PrependReactor("update", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if raced {
+			return false, nil, nil
+		}
+		raced = true
+		obj, err := client.Tracker().Get(gvr, "snakefood", ConfigMapName)
+		if err != nil {
+			return true, nil, err
+		}
+		stored := obj.(*corev1.ConfigMap)
+		tables := Tables{}
+		_ = json.Unmarshal([]byte(stored.Data[configMapKey]), &tables)
+		tables[tableKey(key)], _ = insert(tables[tableKey(key)], Entry{Name: "cat", Score: 7})
+		data, _ := json.Marshal(tables)
+		stored.Data[configMapKey] = string(data)
+		if err := client.Tracker().Update(gvr, stored, "snakefood"); err != nil {
+			return true, nil, err
+		}
+		return true, nil, apierrors.NewConflict(gvr.GroupResource(), ConfigMapName, nil)
+	})
+
+	rank, err := cm.Add(ctx, key, Entry{Name: "bob", Score: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !raced || rank != 2 {
+		t.Fatalf("expected bob to place second after the retry, got rank %d", rank)
+	}
+	top, _ := cm.Top(ctx, key)
+	if len(top) != 3 || top[0].Name != "cat" || top[1].Name != "bob" || top[2].Name != "ann" {
+		t.Fatalf("expected no score lost to the race, got %+v", top)
+	}
+}
@@ -0,0 +1,129 @@
+package scores
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// lockRetry is how often a blocked writer tries the lock again.
+	lockRetry = 20 * time.Millisecond
+	// lockTimeout is how long to wait for the lock before giving up.
+	lockTimeout = 5 * time.Second
+)
+
+// File stores every high-score table in one JSON file. Writers hold an
+// advisory lock on a lock file next to it and replace the file atomically,
+// so two terminals saving at once never lose each other's scores.
+type File struct {
+	Path string
+}
+
+// DataDir returns $XDG_DATA_HOME/snakeinak8, falling back to
+// ~/.local/share/snakeinak8.
+func DataDir() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "snakeinak8")
+}
+
+// DefaultPath returns scores.json under DataDir.
+func DefaultPath() string {
+	return filepath.Join(DataDir(), "scores.json")
+}
+
+// NewFile returns a store backed by the file at path.
+func NewFile(path string) *File {
+	return &File{Path: path}
+}
+
+// Top returns the table for key, best first.
+func (f *File) Top(_ context.Context, key Key) ([]Entry, error) {
+	tables, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return tables[key.String()], nil
+}
+
+// Players ranks everyone with a score on the given cluster.
+func (f *File) Players(_ context.Context, cluster string) ([]Standing, error) {
+	tables, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return standings(tables, cluster+"/"), nil
+}
+
+// Add records an entry in the table for key. Returns its 1-based rank, or
+// 0 if the score did not place.
+func (f *File) Add(_ context.Context, key Key, e Entry) (int, error) {
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create score directory: %w", err)
+	}
+	unlock, err := f.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	// Re-read under the lock so concurrent writers build on each other.
+	tables, err := f.read()
+	if err != nil {
+		return 0, err
+	}
+	table, rank := insert(tables[key.String()], e)
+	if rank == 0 {
+		return 0, nil
+	}
+	tables[key.String()] = table
+	return rank, f.write(tables)
+}
+
+// read loads every table. A missing file is an empty store.
+func (f *File) read() (Tables, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Tables{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scores: %w", err)
+	}
+	tables := Tables{}
+	if err := json.Unmarshal(data, &tables); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", f.Path, err)
+	}
+	return tables, nil
+}
+
+// write replaces the file with tables via a temporary file and rename.
+func (f *File) write(tables Tables) error {
+	data, err := json.MarshalIndent(tables, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scores: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), ".scores-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to save scores: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save scores: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save scores: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return fmt.Errorf("failed to save scores: %w", err)
+	}
+	return nil
+}
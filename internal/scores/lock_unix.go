@@ -0,0 +1,41 @@
+//go:build unix
+
+package scores
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// lock takes an advisory lock on the lock file, waiting for other writers.
+// The kernel drops the lock when its holder exits, so a crashed writer
+// never leaves the scores locked. The lock file itself stays in place:
+// removing it would let a waiter lock a file nobody else opens any more.
+func (f *File) lock() (func(), error) {
+	lf, err := os.OpenFile(f.Path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock scores: %w", err)
+	}
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		err := syscall.Flock(int(lf.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return func() {
+				_ = syscall.Flock(int(lf.Fd()), syscall.LOCK_UN)
+				lf.Close()
+			}, nil
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) && !errors.Is(err, syscall.EINTR) {
+			lf.Close()
+			return nil, fmt.Errorf("failed to lock scores: %w", err)
+		}
+		if time.Now().After(deadline) {
+			lf.Close()
+			return nil, fmt.Errorf("failed to lock scores: %s is held by another process", lf.Name())
+		}
+		time.Sleep(lockRetry)
+	}
+}
@@ -0,0 +1,123 @@
+// Package scores keeps high-score tables, one per cluster, namespace and
+// game mode.
+package scores
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxEntries is how many scores each table keeps.
+const MaxEntries = 10
+
+// Store is where high scores are kept: a local file, or a ConfigMap shared
+// by everyone playing on the cluster.
+type Store interface {
+	// Top returns the table for key, best first.
+	Top(ctx context.Context, key Key) ([]Entry, error)
+	// Add records an entry in the table for key. Returns its 1-based
+	// rank, or 0 if the score did not place.
+	Add(ctx context.Context, key Key, e Entry) (int, error)
+	// Players ranks everyone with a score on the given cluster.
+	Players(ctx context.Context, cluster string) ([]Standing, error)
+}
+
+// Key identifies one high-score table.
+type Key struct {
+	Cluster   string
+	Namespace string // empty for all namespaces
+	Mode      string
+}
+
+// String returns the key as stored, e.g. "kind-dev/snakefood/classic".
+func (k Key) String() string {
+	ns := k.Namespace
+	if ns == "" {
+		ns = "all"
+	}
+	return fmt.Sprintf("%s/%s/%s", k.Cluster, ns, k.Mode)
+}
+
+// Entry is one line of a high-score table.
+type Entry struct {
+	Name     string        `json:"name"`
+	Score    int           `json:"score"`
+	Kills    int           `json:"kills"`
+	Duration time.Duration `json:"duration"`
+	Date     time.Time     `json:"date"`
+}
+
+// Tables maps Key.String() to a table sorted best first.
+type Tables map[string][]Entry
+
+// Standing is one player's record across every table of a cluster.
+type Standing struct {
+	Name  string
+	Best  int // best score in any mode or namespace
+	Kills int // pods killed over all recorded games
+	Games int // recorded games
+}
+
+// standings ranks the players in the tables whose keys start with prefix,
+// best score first.
+func standings(tables Tables, prefix string) []Standing {
+	byName := make(map[string]*Standing)
+	for key, table := range tables {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		for _, e := range table {
+			s, ok := byName[e.Name]
+			if !ok {
+				s = &Standing{Name: e.Name}
+				byName[e.Name] = s
+			}
+			s.Best = max(s.Best, e.Score)
+			s.Kills += e.Kills
+			s.Games++
+		}
+	}
+	out := make([]Standing, 0, len(byName))
+	for _, s := range byName {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Best != out[j].Best {
+			return out[i].Best > out[j].Best
+		}
+		if out[i].Kills != out[j].Kills {
+			return out[i].Kills > out[j].Kills
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// Qualifies returns true if a score would make it onto the table.
+func Qualifies(table []Entry, score int) bool {
+	if score <= 0 {
+		return false
+	}
+	return len(table) < MaxEntries || score > table[len(table)-1].Score
+}
+
+// insert adds e to the table, keeping it sorted and at most MaxEntries
+// long. Returns the new table and the 1-based rank of e, or 0 if it did not
+// place. Ties rank below earlier entries.
+func insert(table []Entry, e Entry) ([]Entry, int) {
+	if !Qualifies(table, e.Score) {
+		return table, 0
+	}
+	i := sort.Search(len(table), func(i int) bool { return table[i].Score < e.Score })
+	out := make([]Entry, 0, len(table)+1)
+	out = append(out, table[:i]...)
+	out = append(out, e)
+	out = append(out, table[i:]...)
+	if len(out) > MaxEntries {
+		out = out[:MaxEntries]
+	}
+	return out, i + 1
+}
@@ -0,0 +1,118 @@
+package scores
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInsertKeepsTheBest(t *testing.T) {
+	var table []Entry
+	for i := 1; i <= MaxEntries+2; i++ {
+		table, _ = insert(table, Entry{Name: fmt.Sprint(i), Score: i})
+	}
+	if len(table) != MaxEntries {
+		t.Fatalf("expected %d entries, got %d", MaxEntries, len(table))
+	}
+	if table[0].Score != MaxEntries+2 || table[MaxEntries-1].Score != 3 {
+		t.Fatalf("expected scores %d..3, got %+v", MaxEntries+2, table)
+	}
+	if _, rank := insert(table, Entry{Score: 3}); rank != 0 {
+		t.Fatal("expected a tie with the last entry not to place")
+	}
+	// 12 down to 6 stay ahead, and so does the earlier 5.
+	if _, rank := insert(table, Entry{Score: 5}); rank != 9 {
+		t.Fatalf("expected rank 9, got %d", rank)
+	}
+}
+
+func TestFileKeysTables(t *testing.T) {
+	ctx := context.Background()
+	f := NewFile(filepath.Join(t.TempDir(), "scores.json"))
+	classic := Key{Cluster: "kind", Mode: "classic"}
+	zen := Key{Cluster: "kind", Namespace: "snakefood", Mode: "zen"}
+
+	if rank, err := f.Add(ctx, classic, Entry{Name: "ann", Score: 4}); err != nil || rank != 1 {
+		t.Fatalf("expected rank 1, got %d (%v)", rank, err)
+	}
+	if _, err := f.Add(ctx, zen, Entry{Name: "bob", Score: 9}); err != nil {
+		t.Fatal(err)
+	}
+
+	top, err := f.Top(ctx, classic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 1 || top[0].Name != "ann" {
+		t.Fatalf("expected only ann in the classic table, got %+v", top)
+	}
+}
+
+func TestFileConcurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scores.json")
+	key := Key{Cluster: "kind", Mode: "classic"}
+
+	// Separate File values stand in for two terminals.
+	var wg sync.WaitGroup
+	for i := 1; i <= MaxEntries; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := NewFile(path).Add(context.Background(), key, Entry{Name: fmt.Sprint(i), Score: i}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	top, err := NewFile(path).Top(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != MaxEntries {
+		t.Fatalf("expected every concurrent write to survive, got %d entries", len(top))
+	}
+}
+
+func TestFileIgnoresLeftoverLockFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scores.json")
+	// A crashed writer leaves the lock file behind, but not its lock.
+	if err := os.WriteFile(path+".lock", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFile(path).Add(context.Background(), Key{Mode: "classic"}, Entry{Name: "ann", Score: 1}); err != nil {
+		t.Fatalf("expected a leftover lock file not to block, got %v", err)
+	}
+}
+
+func TestFileWaitsForTheLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scores.json")
+	key := Key{Mode: "classic"}
+	unlock, err := NewFile(path).lock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := NewFile(path).Add(context.Background(), key, Entry{Name: "ann", Score: 1})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("expected the writer to wait for the lock, it finished with %v", err)
+	case <-time.After(5 * lockRetry):
+	}
+
+	unlock()
+	if err := <-done; err != nil {
+		t.Fatalf("expected the writer to take the released lock, got %v", err)
+	}
+	if top, _ := NewFile(path).Top(context.Background(), key); len(top) != 1 {
+		t.Fatalf("expected the waiting write to land, got %v", top)
+	}
+}
@@ -2,81 +2,387 @@ package ui
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/kristinb/snakeinak8/internal/game"
 	"github.com/kristinb/snakeinak8/internal/k8s"
+	"github.com/kristinb/snakeinak8/internal/scores"
 )
 
 const (
-	defaultTickRate = 150 * time.Millisecond
-	boardWidth      = 40
-	boardHeight     = 20
+	// decoySpawnTicks is how many ticks to wait between decoy fetches.
+	decoySpawnTicks = 60
+
+	// autopilotRestartTicks is how long the game-over screen stays up
+	// before autopilot starts a new game.
+	autopilotRestartTicks = 50
 )
 
-// tickMsg fires on every game tick.
-type tickMsg time.Time
+// tickMsg fires on every game tick. It carries the number of the game that
+// scheduled it, so a tick still in flight when a game is restarted does not
+// drive the next one too.
+type tickMsg struct {
+	game int
+}
 
 // podPlacedMsg signals a new pod was fetched from k8s and should appear.
 type podPlacedMsg struct {
 	Name      string
 	Namespace string
+	Node      string
+	Protected bool // fetched as a decoy
+	Phase     string
+	Restarts  int
+	CPUMillis int64
+	Labels    map[string]string
+	Owner     string
+	Created   time.Time
+	Image     string
 	Err       error
 }
 
 // podKilledMsg signals a pod was deleted from the cluster.
 type podKilledMsg struct {
-	Name      string
-	Namespace string
-	Err       error
+	Pod    game.Pod
+	Player int // index of the player credited with the kill
+	Err    error
 }
 
+// Kill log results.
+const (
+	resultPending    = "pending" // eaten, the delete has not come back yet
+	resultKilled     = "killed"
+	resultFailed     = "failed"
+	resultSpared     = "spared"
+	resultDecoy      = "decoy"
+	resultEvaporated = "evaporated"
+	resultHardened   = "hardened"
+)
+
+// resultMarkers prefix kill log lines so the result reads without the
+// log's color.
+var resultMarkers = map[string]string{
+	resultPending:    ".",
+	resultKilled:     "x",
+	resultFailed:     "?",
+	resultSpared:     "-",
+	resultDecoy:      "!",
+	resultEvaporated: "~",
+	resultHardened:   "#",
+}
+
+// killEntry is one line of the kill log, credited to the player it concerns.
+type killEntry struct {
+	Player int // index into game.Players, -1 for things the cluster did
+	Time   time.Time
+	Result string
+	Pod    game.Pod // the pod as it was when it left the board
+	Text   string
+}
+
+// Movement keys. With one player both sets steer the same snake; with two,
+// WASD belongs to P1 and the arrows to P2.
+var (
+	wasdKeys = map[string]game.Direction{
+		"w": game.Up, "s": game.Down, "a": game.Left, "d": game.Right,
+	}
+	arrowKeys = map[string]game.Direction{
+		"up": game.Up, "down": game.Down, "left": game.Left, "right": game.Right,
+	}
+)
+
 // GameModel is the top-level Bubble Tea model for the game.
 type GameModel struct {
 	game        *game.Game
 	theme       Theme
-	killLog     []string
-	knownPods   map[string]bool // pods currently on board or recently killed
+	killLog     []killEntry
+	knownPods   map[string]bool // pods currently on board, recently killed or hardened
 	clusterName string
 	namespace   string
 	k8sClient   *k8s.Client
 	width       int
 	height      int
-	tickRate    time.Duration
-	fetching    bool   // true while a pod fetch is in flight
-	kubeconfig  string // needed to rebuild menu on return
-	podStatus   string // status message for pod fetching
+	fetching    bool            // true while a pod fetch is in flight
+	kubeconfig  string          // needed to rebuild menu on return
+	podStatus   string          // status message for pod fetching
+	itemWait    int             // ticks until the next power-up fetch, -1 while in flight
+	itemStatus  string          // last power-up picked up
+	decoyWait   int             // ticks until the next decoy fetch, -1 while in flight
+	watchCtx    context.Context // lives as long as this game; cancelled on leave
+	stopWatch   context.CancelFunc
+	podEvents   <-chan k8s.PodEvent
+	options     GameOptions
+	nsOrder     []string                  // namespaces in the order they were first seen
+	nsColors    map[string]lipgloss.Color // legend colors, all-namespaces mode only
+	attract     bool                      // autopilot started by an idle menu; any key leaves
+	idleSeq     int                       // the menu's idle timer count, handed back on return
+	number      int                       // games started so far, this one included
+	limiter     *k8s.KillLimiter          // kill-rate cap, nil unless on autopilot
+	restartWait int                       // ticks until autopilot starts over
+	scores      scores.Store
+	ended       bool       // the game has ended and scores were looked up
+	entry       *nameEntry // high-score name prompt, nil when not asking
+	scoreStatus string     // where the last saved score placed
+	failedKills int
+	logOpen     bool           // the kill log panel is on screen
+	logScroll   int            // first filtered entry shown
+	logCursor   int            // selected entry among the filtered ones
+	logFilter   []rune         // only entries containing this are listed
+	logTyping   bool           // keys go to the filter
+	logResume   bool           // the panel paused the game and unpauses it on close
+	cursor      *game.Position // pod inspection cursor, only while paused
+	sizeResume  bool           // the game was paused because the terminal shrank
 }
 
 // NewGameModel creates the game model with a connected k8s client.
-func NewGameModel(client *k8s.Client, namespace string, theme Theme, width, height int, kubeconfig string) GameModel {
+func NewGameModel(client *k8s.Client, namespace string, theme Theme, options GameOptions, width, height int, kubeconfig string) GameModel {
 	clusterName := "unknown"
 	if client != nil {
 		clusterName = client.ClusterName()
 	}
 
-	return GameModel{
-		game:        game.New(boardWidth, boardHeight),
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	g := newGame(options, width, height, namespace == "")
+	var limiter *k8s.KillLimiter
+	if options.Autopilot {
+		limiter = k8s.NewKillLimiter(options.KillRate)
+	}
+
+	m := GameModel{
+		game:        g,
 		theme:       theme,
-		killLog:     []string{},
+		killLog:     []killEntry{},
 		knownPods:   make(map[string]bool),
 		clusterName: clusterName,
 		namespace:   namespace,
 		k8sClient:   client,
-		tickRate:    defaultTickRate,
 		width:       width,
 		height:      height,
+		itemWait:    itemSpawnTicks,
+		decoyWait:   decoySpawnTicks,
+		watchCtx:    watchCtx,
+		stopWatch:   stopWatch,
 		kubeconfig:  kubeconfig,
+		options:     options,
+		nsColors:    make(map[string]lipgloss.Color),
+		limiter:     limiter,
+		restartWait: autopilotRestartTicks,
+	}
+	if m.tooSmall() {
+		// A fixed-size board may not fit from the start.
+		g.TogglePause()
+		m.sizeResume = true
 	}
+	return m
+}
+
+// newGame sets up the board and snakes for the given options in a terminal
+// of the given size.
+func newGame(options GameOptions, width, height int, allNamespaces bool) *game.Game {
+	g := game.New(boardDimensions(options.BoardSize, options.cellMode(), width, height, options.snakes(), allNamespaces))
+	g.SetDifficulty(options.difficulty())
+	g.Mode = options.Mode
+	g.Decoys = options.Decoys
+	g.Movement = options.Movement
+	if options.Players > 1 {
+		g.Players[0].Name = "P1"
+		for i := 2; i <= options.Players; i++ {
+			if _, err := g.AddPlayer(fmt.Sprintf("P%d", i)); err != nil {
+				break
+			}
+		}
+	}
+	if options.Bot != nil {
+		_, _ = g.AddBot("AI", options.Bot)
+	}
+	if options.Autopilot {
+		g.Players[0].Name = "autopilot"
+		g.Players[0].Strategy = options.AutopilotStrategy
+		if g.Players[0].Strategy == nil {
+			g.Players[0].Strategy = game.ShortestPath
+		}
+	}
+	return g
+}
+
+// trackNamespace assigns the next theme color to a namespace the first time
+// it is seen. Colors are only used when playing across all namespaces.
+func (m *GameModel) trackNamespace(ns string) {
+	if m.namespace != "" {
+		return
+	}
+	if _, ok := m.nsColors[ns]; ok {
+		return
+	}
+	m.nsColors[ns] = m.theme.NamespaceColor(len(m.nsOrder))
+	m.nsOrder = append(m.nsOrder, ns)
+}
+
+// logKill appends a kill log line about pod credited to a player (-1 for
+// none).
+func (m *GameModel) logKill(player int, result string, pod game.Pod, text string) {
+	m.killLog = append(m.killLog, killEntry{
+		Player: player,
+		Time:   time.Now(),
+		Result: result,
+		Pod:    pod,
+		Text:   text,
+	})
+}
+
+// spawn counts down to the next power-up and decoy and returns the fetches
+// that are due, topping up the pods on the board as well.
+func (m *GameModel) spawn() []tea.Cmd {
+	var cmds []tea.Cmd
+	// Spawn power-ups now and then
+	if m.options.PowerUps && m.itemWait >= 0 && len(m.game.Items) < m.game.MaxItems {
+		if m.itemWait == 0 {
+			m.itemWait = -1
+			cmds = append(cmds, fetchItemCmd(m.k8sClient, m.options.FetchTimeout))
+		} else {
+			m.itemWait--
+		}
+	}
+
+	// Replenish pods on the board, holding back food while the
+	// kill-rate cap would not allow eating it
+	budget := m.limiter.Remaining(time.Now())
+	if m.game.EdibleCount() < m.game.MaxPods && !m.fetching && (budget < 0 || m.game.EdibleCount() < budget) {
+		m.fetching = true
+		cmds = append(cmds, fetchPodCmd(m.k8sClient, m.knownPods, false, m.options.FetchTimeout))
+	}
+
+	// Scatter protected pods as decoys
+	if m.game.Decoys != game.DecoysOff && m.decoyWait >= 0 && m.game.DecoyCount() < m.game.MaxDecoys {
+		if m.decoyWait == 0 {
+			m.decoyWait = -1
+			cmds = append(cmds, fetchPodCmd(m.k8sClient, m.knownPods, true, m.options.FetchTimeout))
+		} else {
+			m.decoyWait--
+		}
+	}
+	return cmds
+}
+
+// settleKill records how the delete of an eaten pod turned out, on the
+// pending log line written when it was eaten.
+func (m *GameModel) settleKill(pod game.Pod, result, text string) {
+	for i := len(m.killLog) - 1; i >= 0; i-- {
+		e := &m.killLog[i]
+		if e.Result == resultPending && e.Pod.Name == pod.Name && e.Pod.Namespace == pod.Namespace {
+			e.Result, e.Text = result, text
+			return
+		}
+	}
+	m.logKill(pod.EatenBy, result, pod, text)
+}
+
+// steer turns the human snake that owns the pressed key. Bots ignore the
+// keyboard.
+func (m *GameModel) steer(key string) {
+	var humans []*game.Player
+	for _, p := range m.game.Players {
+		if p.Strategy == nil {
+			humans = append(humans, p)
+		}
+	}
+	if len(humans) == 0 {
+		return
+	}
+	if d, ok := wasdKeys[key]; ok {
+		humans[0].Snake.SetDirection(d)
+	}
+	if d, ok := arrowKeys[key]; ok {
+		humans[len(humans)-1].Snake.SetDirection(d)
+	}
+}
+
+// boardTheme is the theme the board is drawn with: the menu's, with the
+// glyphs that only color tells apart swapped for distinct ones when asked
+// for.
+func (m GameModel) boardTheme() Theme {
+	t := m.theme
+	if m.options.DistinctGlyphs {
+		t.Glyphs = t.Glyphs.distinct()
+	}
+	return t
+}
+
+// focus returns the snake whose surroundings the pod info panel describes:
+// the first living human, or any living snake when only bots are left.
+func (m GameModel) focus() *game.Player {
+	alive := m.game.Alive()
+	for _, p := range alive {
+		if p.Strategy == nil {
+			return p
+		}
+	}
+	if len(alive) > 0 {
+		return alive[0]
+	}
+	return nil
+}
+
+// moveCursor moves the inspection cursor while paused, starting it on the
+// focused snake's head.
+func (m *GameModel) moveCursor(key string) {
+	if m.cursor == nil {
+		p := m.focus()
+		if p == nil {
+			return
+		}
+		head := p.Snake.Head()
+		m.cursor = &head
+	}
+	d, ok := wasdKeys[key]
+	if !ok {
+		d = arrowKeys[key]
+	}
+	pos := *m.cursor
+	switch d {
+	case game.Up:
+		pos.Y = max(pos.Y-1, 0)
+	case game.Down:
+		pos.Y = min(pos.Y+1, m.game.Board.Height-1)
+	case game.Left:
+		pos.X = max(pos.X-1, 0)
+	case game.Right:
+		pos.X = min(pos.X+1, m.game.Board.Width-1)
+	}
+	m.cursor = &pos
+}
+
+// inspected returns the pod shown in the pod info panel and the panel's
+// title: the pod under the cursor while there is one, otherwise the pod
+// nearest the focused snake's head.
+func (m GameModel) inspected() (string, *game.Pod) {
+	if m.cursor != nil {
+		if pod, ok := m.game.PodAt(*m.cursor); ok {
+			return "under cursor", &pod
+		}
+		return "under cursor", nil
+	}
+	p := m.focus()
+	if p == nil {
+		return "nearest pod", nil
+	}
+	if pod, ok := m.game.NearestPod(p.Snake.Head()); ok {
+		return "nearest pod", &pod
+	}
+	return "nearest pod", nil
 }
 
 // Init starts the tick loop and kicks off the first pod fetch.
 func (m GameModel) Init() tea.Cmd {
+	// The first pods are fetched on the first tick, unless the game
+	// starts paused.
 	return tea.Batch(
-		tickCmd(m.tickRate),
-		fetchPodCmd(m.k8sClient, m.knownPods),
+		tickCmd(m.game.TickRate(), m.number),
+		watchPodsCmd(m.watchCtx, m.k8sClient),
 	)
 }
 
@@ -85,66 +391,224 @@ func (m GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case tea.KeyMsg:
+		if m.attract && msg.String() != "ctrl+c" {
+			// Someone walked up to the display: hand the menu back.
+			m.stopWatch()
+			menu := NewMenuModelFromGame(m)
+			return menu, menu.waitIdle()
+		}
+		if m.entry != nil && msg.String() != "ctrl+c" {
+			return m.updateNameEntry(msg)
+		}
+		if m.logOpen && msg.String() != "ctrl+c" {
+			return m.updateFullLog(msg)
+		}
+		if m.ended && msg.String() != "ctrl+c" {
+			return m.updateGameOver(msg)
+		}
+		if m.tooSmall() && msg.String() != "ctrl+c" && msg.String() != "esc" {
+			return m, nil
+		}
 		switch msg.String() {
 		case "ctrl+c":
+			m.stopWatch()
 			return m, tea.Quit
 		case "esc":
+			m.stopWatch()
 			menu := NewMenuModelFromGame(m)
-			return menu, nil
-		case "up", "w":
-			m.game.Snake.SetDirection(game.Up)
-		case "down", "s":
-			m.game.Snake.SetDirection(game.Down)
-		case "left", "a":
-			m.game.Snake.SetDirection(game.Left)
-		case "right", "d":
-			m.game.Snake.SetDirection(game.Right)
+			return menu, menu.waitIdle()
+		case "up", "down", "left", "right", "w", "a", "s", "d":
+			if m.game.State == game.StatePaused {
+				m.moveCursor(msg.String())
+			} else {
+				m.steer(msg.String())
+			}
 		case " ":
 			m.game.TogglePause()
+			m.cursor = nil
+		case "l":
+			m.openLog()
 		}
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		// Hold the game while the board does not fit on screen.
+		switch {
+		case m.tooSmall() && m.game.State == game.StateRunning:
+			m.game.TogglePause()
+			m.sizeResume = true
+		case !m.tooSmall() && m.sizeResume:
+			if m.game.State == game.StatePaused {
+				m.game.TogglePause()
+			}
+			m.sizeResume = false
+		}
 
 	case tickMsg:
+		if msg.game != m.number {
+			return m, nil // left over from the game before a restart
+		}
 		eaten := m.game.Tick()
 		var cmds []tea.Cmd
 
+		if m.options.Autopilot && (m.game.State == game.StateOver || m.game.State == game.StateWon) {
+			if m.restartWait--; m.restartWait <= 0 {
+				m.stopWatch()
+				return NewMenuModelFromGame(m).start(m.attract)
+			}
+		}
+
 		for _, pod := range eaten {
-			m.killLog = append(m.killLog, pod.Namespace+"/"+pod.Name)
-			cmds = append(cmds, killPodCmd(m.k8sClient, pod.Name, pod.Namespace))
+			if !m.limiter.Allow(time.Now()) {
+				// Over the kill-rate cap: the pod leaves the board but
+				// stays alive in the cluster, and scores nothing.
+				m.game.Spare(pod)
+				m.logKill(pod.EatenBy, resultSpared, pod, "spared: "+pod.Namespace+"/"+pod.Name+" (kill-rate cap)")
+				delete(m.knownPods, pod.Name)
+				continue
+			}
+			m.logKill(pod.EatenBy, resultPending, pod, "killing: "+pod.Namespace+"/"+pod.Name)
+			by := m.game.Players[pod.EatenBy].Name
+			cmds = append(cmds, killPodCmd(m.k8sClient, pod, by, m.options.FetchTimeout))
+		}
+		for _, pod := range m.game.DecoysHit {
+			m.logKill(pod.EatenBy, resultDecoy, pod, "DECOY: "+pod.Namespace+"/"+pod.Name+" is protected -- spared")
+			if m.game.Decoys == game.DecoysCostPoints {
+				delete(m.knownPods, pod.Name)
+			}
+		}
+		if !m.ended && (m.game.State == game.StateOver || m.game.State == game.StateWon) {
+			m.ended = true
+			if m.scores != nil && !m.options.Autopilot {
+				cmds = append(cmds, loadScoresCmd(m.scores, m.scoreKey()))
+			}
+		}
+
+		for _, pod := range m.game.Hardened {
+			// Still running, but a wall for the rest of the game: keep it
+			// known so it is never fetched again as food.
+			m.logKill(-1, resultHardened, pod, "hardened: "+pod.Namespace+"/"+pod.Name+" is a wall now")
+		}
+		for _, item := range m.game.Collected {
+			m.itemStatus = fmt.Sprintf("picked up %s %s/%s", item.Kind, item.Namespace, item.Name)
 		}
 
-		// Replenish pods on the board
-		if len(m.game.Pods) < m.game.MaxPods && !m.fetching {
-			m.fetching = true
-			cmds = append(cmds, fetchPodCmd(m.k8sClient, m.knownPods))
+		// Nothing spawns, and the cluster is left alone, while the game is
+		// paused or over.
+		if m.game.State == game.StateRunning {
+			cmds = append(cmds, m.spawn()...)
 		}
 
-		cmds = append(cmds, tickCmd(m.tickRate))
+		cmds = append(cmds, tickCmd(m.game.TickRate(), m.number))
 		return m, tea.Batch(cmds...)
 
 	case podPlacedMsg:
+		if msg.Protected {
+			m.decoyWait = decoySpawnTicks
+			if msg.Name != "" && !m.knownPods[msg.Name] &&
+				m.game.AddPod(game.Pod{
+					Name:      msg.Name,
+					Namespace: msg.Namespace,
+					Node:      msg.Node,
+					Protected: true,
+					Labels:    msg.Labels,
+					Owner:     msg.Owner,
+					Created:   msg.Created,
+					Image:     msg.Image,
+				}) {
+				m.knownPods[msg.Name] = true
+			}
+			return m, nil
+		}
 		m.fetching = false
 		if msg.Err != nil {
 			m.podStatus = "fetch error: " + msg.Err.Error()
 		} else if msg.Name == "" {
 			m.podStatus = "no snakefood pods found -- run: make deploy-small"
 		} else if !m.knownPods[msg.Name] {
-			if m.game.PlacePod(msg.Name, msg.Namespace) {
+			phase, _ := game.ParsePodPhase(msg.Phase)
+			if m.game.AddPod(game.Pod{
+				Name:      msg.Name,
+				Namespace: msg.Namespace,
+				Node:      msg.Node,
+				Phase:     phase,
+				Restarts:  msg.Restarts,
+				CPUMillis: msg.CPUMillis,
+				Labels:    msg.Labels,
+				Owner:     msg.Owner,
+				Created:   msg.Created,
+				Image:     msg.Image,
+			}) {
 				m.knownPods[msg.Name] = true
+				m.trackNamespace(msg.Namespace)
 				m.podStatus = ""
 			}
 		}
 
+	case itemPlacedMsg:
+		m.itemWait = itemSpawnTicks
+		if msg.Err != nil {
+			m.itemStatus = "power-up fetch error: " + msg.Err.Error()
+		} else if msg.Name != "" {
+			m.game.AddItem(game.Item{
+				Kind:      powerUpFor(msg.Kind),
+				Name:      msg.Name,
+				Namespace: msg.Namespace,
+			})
+		}
+
+	case watchStartedMsg:
+		if msg.err != nil {
+			m.podStatus = "watch error: " + msg.err.Error()
+			return m, rewatchCmd(m.watchCtx, m.k8sClient)
+		}
+		m.resyncPods(msg.pods)
+		m.podEvents = msg.events
+		return m, nextPodEventCmd(m.podEvents)
+
+	case watchClosedMsg:
+		return m, rewatchCmd(m.watchCtx, m.k8sClient)
+
+	case podEventMsg:
+		m.applyPodEvent(k8s.PodEvent(msg))
+		return m, nextPodEventCmd(m.podEvents)
+
+	case scoresLoadedMsg:
+		if msg.err != nil {
+			m.scoreStatus = "high scores unavailable: " + msg.err.Error()
+		} else {
+			m.askNames(msg.entries)
+		}
+
+	case scoreSavedMsg:
+		who := m.game.Players[msg.player].Name
+		switch {
+		case msg.err != nil:
+			m.scoreStatus = "could not save score: " + msg.err.Error()
+		case msg.rank > 0:
+			m.scoreStatus = fmt.Sprintf("%s placed #%d on %s", who, msg.rank, m.scoreKey())
+		default:
+			m.scoreStatus = who + " was just pipped to the table"
+		}
+
+	case reportSavedMsg:
+		if msg.err != nil {
+			m.scoreStatus = msg.err.Error()
+		} else {
+			m.scoreStatus = "report saved to " + msg.path
+		}
+
 	case podKilledMsg:
 		if msg.Err != nil {
-			m.killLog = append(m.killLog, "FAILED: "+msg.Namespace+"/"+msg.Name+" -- "+msg.Err.Error())
+			m.failedKills++
+			m.settleKill(msg.Pod, resultFailed, "FAILED: "+msg.Pod.Namespace+"/"+msg.Pod.Name+" -- "+msg.Err.Error())
+		} else {
+			m.settleKill(msg.Pod, resultKilled, "killed: "+msg.Pod.Namespace+"/"+msg.Pod.Name)
 		}
 		// Pod is dead, remove from known so the name slot is freed
 		// (won't come back from the API anyway since it's deleted)
-		delete(m.knownPods, msg.Name)
+		delete(m.knownPods, msg.Pod.Name)
 	}
 
 	return m, nil
@@ -155,6 +619,12 @@ func (m GameModel) View() string {
 	if m.width == 0 {
 		return "initializing..."
 	}
+	if m.logOpen {
+		return m.viewFullLog()
+	}
+	if m.tooSmall() {
+		return m.viewTooSmall()
+	}
 
 	stateLabel := "running"
 	switch m.game.State {
@@ -162,21 +632,43 @@ func (m GameModel) View() string {
 		stateLabel = "paused"
 	case game.StateOver:
 		stateLabel = "GAME OVER"
+		if m.game.Mode == game.ModeTimeAttack && m.game.TimeLeft() == 0 {
+			stateLabel = "TIME UP"
+		}
+		if len(m.game.Players) > 1 {
+			stateLabel += " -- " + m.leader()
+		}
+	case game.StateWon:
+		stateLabel = "BOARD CLEARED -- YOU WIN"
 	}
 
-	header := RenderHeader(m.theme, m.width, m.clusterName)
-	board := RenderBoard(m.theme, m.game)
-	footer := RenderFooter(m.theme, m.width, m.game.Score, m.game.KillCount, stateLabel)
-
-	// Kill log: show last 5 kills
-	var killLines string
-	start := len(m.killLog) - 5
-	if start < 0 {
-		start = 0
+	badge := ""
+	if m.options.Autopilot {
+		badge = "AUTOPILOT"
+	}
+	header := RenderHeader(m.theme, m.width, m.clusterName, badge)
+	board := RenderBoard(m.boardTheme(), m.game, m.nsColors, m.cursor, m.options.cellMode())
+	if m.ended {
+		board = m.viewGameOver(lipgloss.Width(board), lipgloss.Height(board))
+	} else if lipgloss.Width(board)+podInfoSpace <= m.width {
+		title, pod := m.inspected()
+		board = lipgloss.JoinHorizontal(lipgloss.Top, board, RenderPodInfo(m.theme, title, pod, time.Now()))
 	}
-	for _, entry := range m.killLog[start:] {
-		killLines += m.theme.KillLogStyle.Render("  killed: "+entry) + "\n"
+	if m.namespace == "" && lipgloss.Width(board)+legendSpace <= m.width {
+		legend := RenderLegend(m.boardTheme(), m.nsOrder, m.nsColors, lipgloss.Height(board))
+		board = lipgloss.JoinHorizontal(lipgloss.Top, board, legend)
 	}
+	footer := RenderFooter(m.theme, m.width, FooterStats{
+		Score:    m.game.Score,
+		Kills:    m.game.KillCount,
+		Level:    m.game.Level,
+		TickRate: m.game.TickRate().String(),
+		State:    stateLabel,
+		Mode:     m.modeLabel(),
+		Players:  m.playerScores(),
+	})
+
+	killLines := m.renderKillLog()
 
 	var statusLine string
 	if m.podStatus != "" {
@@ -185,12 +677,51 @@ func (m GameModel) View() string {
 			Italic(true).
 			Render("  " + m.podStatus)
 	}
+	if m.scoreStatus != "" {
+		statusLine = lipgloss.NewStyle().
+			Foreground(m.theme.Accent).
+			Render("  " + m.scoreStatus)
+	}
+
+	var effectLines []string
+	for _, p := range m.game.Players {
+		label := "active"
+		if len(m.game.Players) > 1 {
+			label = p.Name + " active"
+		}
+		if e := renderEffects(m.theme, label, p.Effects); e != "" {
+			effectLines = append(effectLines, e)
+		}
+	}
+	effects := lipgloss.JoinVertical(lipgloss.Left, effectLines...)
+	if m.itemStatus != "" {
+		effects = lipgloss.JoinVertical(lipgloss.Left, effects, lipgloss.NewStyle().
+			Foreground(m.theme.PowerUpColor).
+			Italic(true).
+			Render("  "+m.itemStatus))
+	}
 
 	nsLabel := "all"
 	if m.namespace != "" {
 		nsLabel = m.namespace
 	}
-	controls := m.theme.FooterStyle.Render("[wasd/arrows] move  [space] pause  [esc] menu  ns:" + nsLabel)
+	keys := "[wasd/arrows] move"
+	if m.options.Players > 1 {
+		keys = "[wasd] P1  [arrows] P2"
+	}
+	if m.options.Autopilot {
+		keys = fmt.Sprintf("autopilot, at most %d kills/min", m.options.KillRate)
+		if m.options.KillRate <= 0 {
+			keys = "autopilot, no kill-rate cap"
+		}
+	}
+	if m.game.State == game.StatePaused {
+		keys = "[wasd/arrows] inspect pods"
+	}
+	controls := m.theme.FooterStyle.Render(keys + "  [space] pause  [l] kill log  [esc] menu  ns:" + nsLabel)
+	if m.attract {
+		controls = m.theme.FooterStyle.Render(keys + "  [any key] menu  ns:" + nsLabel)
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		header,
@@ -198,19 +729,98 @@ func (m GameModel) View() string {
 		board,
 		"",
 		killLines,
+		effects,
 		statusLine,
 		footer,
 		controls,
 	)
 }
 
-func tickCmd(d time.Duration) tea.Cmd {
-	return tea.Tick(d, func(t time.Time) tea.Msg {
-		return tickMsg(t)
+// renderKillLog shows the last five entries. With two players the log is
+// split into one column per player plus one for pods that died on their own.
+func (m GameModel) renderKillLog() string {
+	const shown = 5
+	tail := func(player int, all bool) []string {
+		var lines []string
+		for _, e := range m.killLog {
+			if all || e.Player == player {
+				lines = append(lines, m.theme.KillLogStyle.Render("  "+resultMarkers[e.Result]+" "+e.Text))
+			}
+		}
+		if len(lines) > shown {
+			lines = lines[len(lines)-shown:]
+		}
+		return lines
+	}
+
+	if len(m.game.Players) < 2 {
+		lines := tail(0, true)
+		if len(lines) == 0 {
+			return ""
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	var columns []string
+	for i, p := range m.game.Players {
+		title := m.theme.ScoreStyle.Render("  " + p.Name)
+		columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, append([]string{title}, tail(i, false)...)...))
+	}
+	if cluster := tail(-1, false); len(cluster) > 0 {
+		title := m.theme.StatusStyle.Render("  cluster")
+		columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, append([]string{title}, cluster...)...))
+	}
+	for i := range columns[:len(columns)-1] {
+		columns[i] = lipgloss.NewStyle().PaddingRight(2).Render(columns[i])
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...) + "\n"
+}
+
+// modeLabel names the game mode for the footer, counting down the clock in
+// a time attack.
+func (m GameModel) modeLabel() string {
+	if m.game.Mode == game.ModeTimeAttack {
+		left := (m.game.TimeLeft() + time.Second - 1) / time.Second
+		return fmt.Sprintf("%s %ds", m.game.Mode, left)
+	}
+	return m.game.Mode.String()
+}
+
+// playerScores lists every player's score for the footer.
+func (m GameModel) playerScores() []PlayerScore {
+	scores := make([]PlayerScore, 0, len(m.game.Players))
+	for _, p := range m.game.Players {
+		scores = append(scores, PlayerScore{Name: p.Name, Score: p.Score, Alive: p.Alive})
+	}
+	return scores
+}
+
+// leader names the player with the highest score, or reports a tie.
+func (m GameModel) leader() string {
+	best, tie := m.game.Players[0], false
+	for _, p := range m.game.Players[1:] {
+		switch {
+		case p.Score > best.Score:
+			best, tie = p, false
+		case p.Score == best.Score:
+			tie = true
+		}
+	}
+	if tie {
+		return "DRAW"
+	}
+	return best.Name + " WINS"
+}
+
+func tickCmd(d time.Duration, game int) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return tickMsg{game: game}
 	})
 }
 
-func fetchPodCmd(client *k8s.Client, exclude map[string]bool) tea.Cmd {
+// fetchPodCmd fetches a random edible pod, or a protected pod when decoy is
+// set. Decoys are only ever displayed, never deleted.
+func fetchPodCmd(client *k8s.Client, exclude map[string]bool, decoy bool, timeout time.Duration) tea.Cmd {
 	// Snapshot the exclude set so the goroutine doesn't race with Update.
 	snapshot := make(map[string]bool, len(exclude))
 	for k := range exclude {
@@ -218,29 +828,51 @@ func fetchPodCmd(client *k8s.Client, exclude map[string]bool) tea.Cmd {
 	}
 	return func() tea.Msg {
 		if client == nil {
-			return podPlacedMsg{Err: nil}
+			return podPlacedMsg{Protected: decoy}
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
-		pod, err := client.RandomPod(ctx, snapshot)
+		pick := client.RandomPod
+		if decoy {
+			pick = client.RandomProtectedPod
+		}
+		pod, err := pick(ctx, snapshot)
 		if err != nil {
-			return podPlacedMsg{Err: err}
+			return podPlacedMsg{Protected: decoy, Err: err}
 		}
 		if pod == nil {
-			return podPlacedMsg{}
+			return podPlacedMsg{Protected: decoy}
+		}
+		return podPlacedMsg{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			Node:      pod.NodeName,
+			Protected: pod.Protected,
+			Phase:     pod.Phase,
+			Restarts:  pod.Restarts,
+			CPUMillis: pod.CPUMillis,
+			Labels:    pod.Labels,
+			Owner:     pod.Owner,
+			Created:   pod.Created,
+			Image:     pod.Image,
 		}
-		return podPlacedMsg{Name: pod.Name, Namespace: pod.Namespace}
 	}
 }
 
-func killPodCmd(client *k8s.Client, name, namespace string) tea.Cmd {
+// killPodCmd deletes the pod and, once it is gone, records an Event
+// crediting the player named by. The Event is best-effort: a missing
+// permission to create events must not turn a kill into a failure.
+func killPodCmd(client *k8s.Client, pod game.Pod, by string, timeout time.Duration) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
-			return podKilledMsg{Name: name, Namespace: namespace}
+			return podKilledMsg{Pod: pod, Player: pod.EatenBy}
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
-		err := client.KillPod(ctx, name, namespace)
-		return podKilledMsg{Name: name, Namespace: namespace, Err: err}
+		err := client.KillPod(ctx, pod.Name, pod.Namespace)
+		if err == nil {
+			_ = client.RecordKill(ctx, pod.Name, pod.Namespace, by)
+		}
+		return podKilledMsg{Pod: pod, Player: pod.EatenBy, Err: err}
 	}
 }
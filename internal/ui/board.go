@@ -1,65 +1,328 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/kristinb/snakeinak8/internal/game"
 )
 
-// Cell characters for rendering.
+// Default cell characters for rendering. Themes may replace all but
+// CellEmpty.
 const (
 	CellEmpty     = " "
 	CellSnakeHead = "@"
 	CellSnakeBody = "#"
 	CellPod       = "*"
 	CellWall      = "."
+	CellHazard    = "x"
+	CellDecoy     = "!"
+	CellPending   = "o"
 )
 
-// RenderBoard draws the game board as a string.
-func RenderBoard(theme Theme, g *game.Game) string {
+// Half-block characters used when packing two rows into a line.
+const (
+	halfUpper = "\u2580"
+	halfLower = "\u2584"
+)
+
+// fadeFrames animate an evaporating pod, from freshest to nearly gone.
+var fadeFrames = []string{"o", "o", ":", ":", ".", "."}
+
+// Default power-up characters, one per resource kind.
+const (
+	CellConfigMap = "C"
+	CellService   = "S"
+	CellSecret    = "$"
+	CellPVC       = "P"
+)
+
+// itemCell returns the character for a power-up.
+func itemCell(g Glyphs, p game.PowerUp) string {
+	switch p {
+	case game.PowerPhase:
+		return g.Service
+	case game.PowerDoublePoints:
+		return g.Secret
+	case game.PowerShrink:
+		return g.PVC
+	default:
+		return g.ConfigMap
+	}
+}
+
+// CellMode selects how board cells map onto terminal cells. Terminal cells
+// are about twice as tall as wide, so the narrow mode stretches the board
+// vertically; the other two draw square cells.
+type CellMode int
+
+const (
+	CellsNarrow CellMode = iota // one column per cell
+	CellsWide                   // two columns per cell
+	CellsHalf                   // half blocks, two cells per line
+)
+
+// CellModes lists the cell modes in menu order.
+func CellModes() []CellMode {
+	return []CellMode{CellsNarrow, CellsWide, CellsHalf}
+}
+
+func (c CellMode) String() string {
+	switch c {
+	case CellsWide:
+		return "wide"
+	case CellsHalf:
+		return "half"
+	default:
+		return "narrow"
+	}
+}
+
+// ParseCellMode parses a cell mode name.
+func ParseCellMode(s string) (CellMode, error) {
+	if s == "" {
+		return CellsNarrow, nil
+	}
+	for _, c := range CellModes() {
+		if c.String() == s {
+			return c, nil
+		}
+	}
+	return CellsNarrow, fmt.Errorf("unknown cell mode %q (want narrow, wide or half)", s)
+}
+
+// screenSize returns how many columns and lines a board of w by h cells
+// takes up, without the border.
+func (c CellMode) screenSize(w, h int) (int, int) {
+	switch c {
+	case CellsWide:
+		return 2 * w, h
+	case CellsHalf:
+		return w, (h + 1) / 2
+	}
+	return w, h
+}
+
+// cell is one board cell before it is drawn. Solid cells (snakes, walls,
+// hazards) repeat their glyph when drawn two columns wide so they stay
+// unbroken; others are padded with a space.
+type cell struct {
+	glyph string
+	style lipgloss.Style
+	solid bool
+}
+
+// RenderBoard draws the game board as a string. Pods whose namespace has an
+// entry in podColors are drawn in that color instead of the theme's. A
+// non-nil cursor highlights that cell.
+func RenderBoard(theme Theme, g *game.Game, podColors map[string]lipgloss.Color, cursor *game.Position, mode CellMode) string {
 	// Build a 2D grid
-	grid := make([][]string, g.Board.Height)
+	grid := make([][]cell, g.Board.Height)
 	for y := range grid {
-		grid[y] = make([]string, g.Board.Width)
+		grid[y] = make([]cell, g.Board.Width)
 		for x := range grid[y] {
-			grid[y][x] = CellEmpty
+			grid[y][x] = cell{glyph: CellEmpty, style: lipgloss.NewStyle()}
+		}
+	}
+
+	// Place walls, hazard rooms and room labels
+	wallStyle := lipgloss.NewStyle().Foreground(theme.WallColor)
+	for p := range g.Board.Walls {
+		if inBounds(p, g.Board) {
+			grid[p.Y][p.X] = cell{glyph: theme.Glyphs.Wall, style: wallStyle, solid: true}
+		}
+	}
+	hazardStyle := lipgloss.NewStyle().Foreground(theme.HazardColor)
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	for _, room := range g.Board.Rooms {
+		if room.Hazard {
+			for y := room.Bounds.Min.Y; y < room.Bounds.Max.Y; y++ {
+				for x := room.Bounds.Min.X; x < room.Bounds.Max.X; x++ {
+					grid[y][x] = cell{glyph: theme.Glyphs.Hazard, style: hazardStyle, solid: true}
+				}
+			}
+		}
+		label := []rune(room.Name)
+		if len(label) > room.Bounds.Width() {
+			label = label[:room.Bounds.Width()]
+		}
+		style := labelStyle
+		if room.Hazard {
+			style = hazardStyle.Bold(true)
+		}
+		for i, r := range label {
+			grid[room.Bounds.Min.Y][room.Bounds.Min.X+i] = cell{glyph: string(r), style: style}
 		}
 	}
 
 	// Place pods
 	podStyle := lipgloss.NewStyle().Foreground(theme.PodColor).Bold(true)
+	decoyStyle := lipgloss.NewStyle().Foreground(theme.HazardColor).Bold(true)
 	for _, pod := range g.Pods {
 		if inBounds(pod.Pos, g.Board) {
-			grid[pod.Pos.Y][pod.Pos.X] = podStyle.Render(CellPod)
+			if pod.Protected {
+				grid[pod.Pos.Y][pod.Pos.X] = cell{glyph: theme.Glyphs.Decoy, style: decoyStyle}
+				continue
+			}
+			style := podStyle
+			if c, ok := podColors[pod.Namespace]; ok {
+				style = style.Foreground(c)
+			}
+			grid[pod.Pos.Y][pod.Pos.X] = renderPod(theme, style, pod, g.Ticks)
 		}
 	}
 
-	// Place snake body
-	bodyStyle := lipgloss.NewStyle().Foreground(theme.SnakeBody)
-	for _, seg := range g.Snake.Body[1:] {
-		if inBounds(seg, g.Board) {
-			grid[seg.Y][seg.X] = bodyStyle.Render(CellSnakeBody)
+	// Place evaporating pods
+	fadeStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	for _, f := range g.Fades {
+		if inBounds(f.Pos, g.Board) {
+			frame := len(fadeFrames) - f.Left
+			if frame < 0 {
+				frame = 0
+			}
+			glyph := fadeFrames[frame]
+			if theme.Glyphs.Fading != "" {
+				glyph = theme.Glyphs.Fading
+			}
+			grid[f.Pos.Y][f.Pos.X] = cell{glyph: glyph, style: fadeStyle}
 		}
 	}
 
-	// Place snake head
-	headStyle := lipgloss.NewStyle().Foreground(theme.SnakeHead).Bold(true)
-	head := g.Snake.Head()
-	if inBounds(head, g.Board) {
-		grid[head.Y][head.X] = headStyle.Render(CellSnakeHead)
+	// Place power-ups
+	itemStyle := lipgloss.NewStyle().Foreground(theme.PowerUpColor).Bold(true)
+	for _, item := range g.Items {
+		if inBounds(item.Pos, g.Board) {
+			grid[item.Pos.Y][item.Pos.X] = cell{glyph: itemCell(theme.Glyphs, item.Kind), style: itemStyle}
+		}
 	}
 
-	// Render rows
-	var rows []string
-	for _, row := range grid {
-		rows = append(rows, strings.Join(row, ""))
+	// Place snakes; crashed players leave the board
+	for i, p := range g.Players {
+		if !p.Alive {
+			continue
+		}
+		headColor, bodyColor := theme.SnakeHead, theme.SnakeBody
+		headGlyph, bodyGlyph := theme.Glyphs.SnakeHead, theme.Glyphs.SnakeBody
+		switch {
+		case p.Strategy != nil:
+			headColor, bodyColor = theme.BotHead, theme.BotBody
+			headGlyph, bodyGlyph = theme.Glyphs.BotHead, theme.Glyphs.BotBody
+		case i > 0:
+			headColor, bodyColor = theme.RivalHead, theme.RivalBody
+			headGlyph, bodyGlyph = theme.Glyphs.RivalHead, theme.Glyphs.RivalBody
+		}
+
+		bodyStyle := lipgloss.NewStyle().Foreground(bodyColor)
+		for _, seg := range p.Snake.Body[1:] {
+			if inBounds(seg, g.Board) {
+				grid[seg.Y][seg.X] = cell{glyph: bodyGlyph, style: bodyStyle, solid: true}
+			}
+		}
+
+		headStyle := lipgloss.NewStyle().Foreground(headColor).Bold(true)
+		head := p.Snake.Head()
+		if inBounds(head, g.Board) {
+			grid[head.Y][head.X] = cell{glyph: headGlyph, style: headStyle, solid: true}
+		}
+	}
+
+	// Highlight the inspection cursor
+	if cursor != nil && inBounds(*cursor, g.Board) {
+		c := &grid[cursor.Y][cursor.X]
+		c.style = c.style.Reverse(true)
+		if mode == CellsHalf {
+			// Half blocks only carry color, so the cursor gets its own.
+			c.glyph, c.style = theme.Glyphs.SnakeHead, lipgloss.NewStyle().Foreground(theme.Accent)
+		}
 	}
-	board := strings.Join(rows, "\n")
 
+	var board string
+	switch mode {
+	case CellsHalf:
+		board = renderHalfBlocks(grid)
+	default:
+		board = renderCells(grid, mode == CellsWide)
+	}
 	return theme.BoardStyle.Render(board)
 }
 
+// renderCells draws one line per row, each cell one column wide or two.
+func renderCells(grid [][]cell, wide bool) string {
+	rows := make([]string, 0, len(grid))
+	for _, row := range grid {
+		var b strings.Builder
+		for _, c := range row {
+			glyph := c.glyph
+			if wide {
+				if c.solid {
+					glyph += c.glyph
+				} else {
+					glyph += CellEmpty
+				}
+			}
+			b.WriteString(c.style.Render(glyph))
+		}
+		rows = append(rows, b.String())
+	}
+	return strings.Join(rows, "\n")
+}
+
+// renderHalfBlocks packs two rows into each line: the upper cell colors the
+// top half of a character and the lower cell the bottom half. Glyphs are
+// lost; every occupied cell shows as a block in its color.
+func renderHalfBlocks(grid [][]cell) string {
+	color := func(y, x int) (lipgloss.TerminalColor, bool) {
+		if y >= len(grid) || grid[y][x].glyph == CellEmpty {
+			return nil, false
+		}
+		c := grid[y][x].style.GetForeground()
+		if _, none := c.(lipgloss.NoColor); none {
+			// Uncolored glyphs still need to show up.
+			c = lipgloss.Color("7")
+		}
+		return c, true
+	}
+
+	rows := make([]string, 0, (len(grid)+1)/2)
+	for y := 0; y < len(grid); y += 2 {
+		var b strings.Builder
+		for x := range grid[y] {
+			top, hasTop := color(y, x)
+			bottom, hasBottom := color(y+1, x)
+			switch {
+			case hasTop && hasBottom:
+				b.WriteString(lipgloss.NewStyle().Foreground(top).Background(bottom).Render(halfUpper))
+			case hasTop:
+				b.WriteString(lipgloss.NewStyle().Foreground(top).Render(halfUpper))
+			case hasBottom:
+				b.WriteString(lipgloss.NewStyle().Foreground(bottom).Render(halfLower))
+			default:
+				b.WriteString(CellEmpty)
+			}
+		}
+		rows = append(rows, b.String())
+	}
+	return strings.Join(rows, "\n")
+}
+
+// renderPod draws a pod according to its phase: pending pods are hollow,
+// crash-looping pods blink and terminating pods fade.
+func renderPod(theme Theme, style lipgloss.Style, pod game.Pod, tick int) cell {
+	switch pod.Phase {
+	case game.PhasePending:
+		return cell{glyph: theme.Glyphs.Pending, style: style}
+	case game.PhaseCrashLoop:
+		if (tick/2)%2 == 0 {
+			return cell{glyph: theme.Glyphs.CrashLoop, style: style.Foreground(theme.HazardColor)}
+		}
+		return cell{glyph: theme.Glyphs.CrashLoop, style: style.Faint(true)}
+	case game.PhaseTerminating:
+		return cell{glyph: theme.Glyphs.Terminating, style: style.Bold(false).Faint(true).Foreground(theme.Dim)}
+	}
+	return cell{glyph: theme.Glyphs.Pod, style: style}
+}
+
 func inBounds(p game.Position, b *game.Board) bool {
 	return p.X >= 0 && p.X < b.Width && p.Y >= 0 && p.Y < b.Height
 }
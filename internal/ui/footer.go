@@ -2,24 +2,56 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
-// RenderFooter draws the bottom status bar with score and kill count.
-func RenderFooter(theme Theme, width, score, kills int, state string) string {
-	left := theme.ScoreStyle.Render(fmt.Sprintf("score: %d", score))
-	mid := theme.KillLogStyle.Render(fmt.Sprintf("pods killed: %d", kills))
-	right := theme.StatusStyle.Render(state)
+// FooterStats is the game state shown in the bottom status bar.
+type FooterStats struct {
+	Score    int
+	Kills    int
+	Level    int
+	TickRate string
+	State    string
+	Mode     string        // game mode, with the time left in a time attack
+	Players  []PlayerScore // per-player scores, only shown with two or more
+}
+
+// PlayerScore is one player's standing in a multiplayer game.
+type PlayerScore struct {
+	Name  string
+	Score int
+	Alive bool
+}
+
+// RenderFooter draws the bottom status bar with score, kill count, level
+// and current speed.
+func RenderFooter(theme Theme, width int, stats FooterStats) string {
+	left := theme.ScoreStyle.Render(fmt.Sprintf("score: %d", stats.Score))
+	if len(stats.Players) > 1 {
+		parts := make([]string, 0, len(stats.Players))
+		for _, p := range stats.Players {
+			part := fmt.Sprintf("%s: %d", p.Name, p.Score)
+			if !p.Alive {
+				part += " (out)"
+			}
+			parts = append(parts, part)
+		}
+		left = theme.ScoreStyle.Render(strings.Join(parts, "  "))
+	}
+	mid := theme.KillLogStyle.Render(fmt.Sprintf("pods killed: %d", stats.Kills))
+	level := theme.StatusStyle.Render(fmt.Sprintf("%s  level %d  tick %s", stats.Mode, stats.Level, stats.TickRate))
+	right := theme.StatusStyle.Render(stats.State)
 
-	totalContent := lipgloss.Width(left) + lipgloss.Width(mid) + lipgloss.Width(right)
+	totalContent := lipgloss.Width(left) + lipgloss.Width(mid) + lipgloss.Width(level) + lipgloss.Width(right)
 	gaps := width - totalContent
-	if gaps < 2 {
-		gaps = 2
+	if gaps < 3 {
+		gaps = 3
 	}
-	spacer := lipgloss.NewStyle().Width(gaps / 2).Render("")
+	spacer := lipgloss.NewStyle().Width(gaps / 3).Render("")
 
-	bar := lipgloss.JoinHorizontal(lipgloss.Top, left, spacer, mid, spacer, right)
+	bar := lipgloss.JoinHorizontal(lipgloss.Top, left, spacer, mid, spacer, level, spacer, right)
 
 	return lipgloss.NewStyle().
 		Background(theme.Background).
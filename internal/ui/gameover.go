@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kristinb/snakeinak8/internal/game"
+	"github.com/kristinb/snakeinak8/internal/scores"
+)
+
+// reportSavedMsg reports where the game report was written.
+type reportSavedMsg struct {
+	path string
+	err  error
+}
+
+// sessionStats sums up a finished game for the game-over screen and the
+// saved report.
+type sessionStats struct {
+	Score       int
+	Kills       int
+	FailedKills int
+	Duration    time.Duration
+	Longest     int
+	PodsPerMin  float64
+}
+
+// stats computes the session numbers. The duration is game time played, so
+// time spent paused does not count.
+func (m GameModel) stats() sessionStats {
+	s := sessionStats{
+		Score:       m.game.Score,
+		Kills:       m.game.KillCount,
+		FailedKills: m.failedKills,
+		Duration:    m.game.Elapsed.Round(time.Second),
+	}
+	for _, p := range m.game.Players {
+		s.Longest = max(s.Longest, p.Longest)
+	}
+	if minutes := m.game.Elapsed.Minutes(); minutes > 0 {
+		s.PodsPerMin = float64(s.Kills) / minutes
+	}
+	return s
+}
+
+// updateGameOver handles the keys of the game-over screen.
+func (m GameModel) updateGameOver(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r":
+		m.stopWatch()
+		return NewMenuModelFromGame(m).start(false)
+	case "l":
+		m.openLog()
+	case "s":
+		return m, saveReportCmd(m.report())
+	case "esc", "q":
+		m.stopWatch()
+		menu := NewMenuModelFromGame(m)
+		return menu, menu.waitIdle()
+	}
+	return m, nil
+}
+
+// viewGameOver renders the overlay shown over the board once the game ends.
+func (m GameModel) viewGameOver(width, height int) string {
+	theme := m.theme
+	s := m.stats()
+
+	title := "GAME OVER"
+	switch {
+	case m.game.State == game.StateWon:
+		title = "BOARD CLEARED"
+	case m.game.Mode == game.ModeTimeAttack && m.game.TimeLeft() == 0:
+		title = "TIME UP"
+	}
+	if len(m.game.Players) > 1 {
+		title += " -- " + m.leader()
+	}
+
+	label := lipgloss.NewStyle().Foreground(theme.Dim)
+	value := lipgloss.NewStyle().Foreground(theme.Foreground).Bold(true)
+	row := func(name, v string) string {
+		return label.Render(fmt.Sprintf("%-13s", name)) + value.Render(v)
+	}
+	rows := []string{
+		lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render(title),
+		"",
+		row("score", fmt.Sprint(s.Score)),
+		row("pods killed", fmt.Sprint(s.Kills)),
+		row("failed kills", fmt.Sprint(s.FailedKills)),
+		row("duration", s.Duration.String()),
+		row("longest", fmt.Sprint(s.Longest)),
+		row("pods/min", fmt.Sprintf("%.1f", s.PodsPerMin)),
+	}
+	if len(m.game.Players) > 1 {
+		rows = append(rows, "")
+		for _, p := range m.game.Players {
+			rows = append(rows, row(p.Name, fmt.Sprintf("%d points, %d kills", p.Score, p.KillCount)))
+		}
+	}
+	if m.entry != nil {
+		rows = append(rows, "", m.viewNameEntry())
+	} else {
+		rows = append(rows, "", label.Render("[r] restart  [l] kill log  [s] save report  [esc] menu"))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(1, 3).
+		Render(strings.Join(rows, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// report renders the finished game as plain text.
+func (m GameModel) report() string {
+	s := m.stats()
+	ns := m.namespace
+	if ns == "" {
+		ns = "all"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "snakeinak8 game report, %s\n\n", time.Now().Format(time.RFC1123))
+	fmt.Fprintf(&b, "cluster:      %s\n", m.clusterName)
+	fmt.Fprintf(&b, "namespace:    %s\n", ns)
+	fmt.Fprintf(&b, "mode:         %s\n", m.game.Mode)
+	fmt.Fprintf(&b, "difficulty:   %s\n\n", m.game.Difficulty.Name)
+	fmt.Fprintf(&b, "score:        %d\n", s.Score)
+	fmt.Fprintf(&b, "pods killed:  %d\n", s.Kills)
+	fmt.Fprintf(&b, "failed kills: %d\n", s.FailedKills)
+	fmt.Fprintf(&b, "duration:     %s\n", s.Duration)
+	fmt.Fprintf(&b, "longest:      %d\n", s.Longest)
+	fmt.Fprintf(&b, "pods/min:     %.1f\n", s.PodsPerMin)
+	if len(m.game.Players) > 1 {
+		b.WriteString("\n")
+		for _, p := range m.game.Players {
+			fmt.Fprintf(&b, "%-13s %d points, %d kills, longest %d\n", p.Name+":", p.Score, p.KillCount, p.Longest)
+		}
+	}
+	b.WriteString("\nkill log:\n")
+	for _, e := range m.killLog {
+		fmt.Fprintf(&b, "  %s [%s] %s\n", e.Time.Format("15:04:05"), m.killer(e), e.Text)
+	}
+	return b.String()
+}
+
+// saveReportCmd writes the report to a new file under the data directory.
+func saveReportCmd(report string) tea.Cmd {
+	return func() tea.Msg {
+		dir := filepath.Join(scores.DataDir(), "reports")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return reportSavedMsg{err: fmt.Errorf("failed to create report directory: %w", err)}
+		}
+		path := filepath.Join(dir, "game-"+time.Now().Format("20060102-150405")+".txt")
+		if err := os.WriteFile(path, []byte(report), 0o644); err != nil {
+			return reportSavedMsg{err: fmt.Errorf("failed to save report: %w", err)}
+		}
+		return reportSavedMsg{path: path}
+	}
+}
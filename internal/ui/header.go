@@ -2,9 +2,18 @@ package ui
 
 import "github.com/charmbracelet/lipgloss"
 
-// RenderHeader draws the top bar with game title and cluster info.
-func RenderHeader(theme Theme, width int, clusterName string) string {
+// RenderHeader draws the top bar with game title and cluster info. A
+// non-empty badge (such as "AUTOPILOT") is shown next to the title.
+func RenderHeader(theme Theme, width int, clusterName, badge string) string {
 	title := theme.HeaderStyle.Render("snakeinak8")
+	if badge != "" {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.Background).
+			Background(theme.Accent).
+			Padding(0, 1).
+			Render(badge))
+	}
 
 	info := lipgloss.NewStyle().
 		Foreground(theme.Dim).
@@ -0,0 +1,280 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kristinb/snakeinak8/internal/game"
+	"github.com/kristinb/snakeinak8/internal/scores"
+)
+
+// maxNameLength bounds names typed into the high-score prompt.
+const maxNameLength = 12
+
+// scoresLoadedMsg carries a high-score table read from the store.
+type scoresLoadedMsg struct {
+	key     scores.Key
+	entries []scores.Entry
+	err     error
+}
+
+// scoreSavedMsg reports where a new score placed.
+type scoreSavedMsg struct {
+	player int
+	rank   int
+	err    error
+}
+
+// nameEntry is the prompt shown after a game for every human that placed.
+type nameEntry struct {
+	queue []int  // players still to be asked, the first one being asked now
+	name  []rune // name typed so far
+}
+
+// playersLoadedMsg carries the leaderboard standings.
+type playersLoadedMsg struct {
+	players []scores.Standing
+	err     error
+}
+
+func loadScoresCmd(store scores.Store, key scores.Key) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		entries, err := store.Top(ctx, key)
+		return scoresLoadedMsg{key: key, entries: entries, err: err}
+	}
+}
+
+func saveScoreCmd(store scores.Store, key scores.Key, player int, e scores.Entry) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		rank, err := store.Add(ctx, key, e)
+		return scoreSavedMsg{player: player, rank: rank, err: err}
+	}
+}
+
+func loadPlayersCmd(store scores.Store, cluster string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		players, err := store.Players(ctx, cluster)
+		return playersLoadedMsg{players: players, err: err}
+	}
+}
+
+// defaultName pre-fills the name prompt with the login name.
+func defaultName() []rune {
+	name := []rune(os.Getenv("USER"))
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return name
+}
+
+// scoreKey returns the table this game's scores go to.
+func (m GameModel) scoreKey() scores.Key {
+	return scores.Key{Cluster: m.clusterName, Namespace: m.namespace, Mode: m.game.Mode.String()}
+}
+
+// askNames starts the name prompt for every human whose score places on
+// the table just loaded. Bots never make the table.
+func (m *GameModel) askNames(table []scores.Entry) {
+	var queue []int
+	for i, p := range m.game.Players {
+		if p.Strategy == nil && scores.Qualifies(table, p.Score) {
+			queue = append(queue, i)
+		}
+	}
+	if len(queue) > 0 {
+		m.entry = &nameEntry{queue: queue, name: defaultName()}
+	}
+}
+
+// updateNameEntry handles typing into the high-score prompt.
+func (m GameModel) updateNameEntry(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	e := m.entry
+	switch msg.Type {
+	case tea.KeyEnter:
+		name := strings.TrimSpace(string(e.name))
+		if name == "" {
+			return m, nil
+		}
+		i := e.queue[0]
+		p := m.game.Players[i]
+		cmd := saveScoreCmd(m.scores, m.scoreKey(), i, scores.Entry{
+			Name:     name,
+			Score:    p.Score,
+			Kills:    p.KillCount,
+			Duration: m.game.Elapsed.Round(time.Second),
+			Date:     time.Now(),
+		})
+		m.nextName()
+		return m, cmd
+	case tea.KeyEsc:
+		m.nextName()
+	case tea.KeyBackspace:
+		if len(e.name) > 0 {
+			e.name = e.name[:len(e.name)-1]
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		if len(e.name)+len(msg.Runes) <= maxNameLength {
+			e.name = append(e.name, msg.Runes...)
+		}
+	}
+	return m, nil
+}
+
+// nextName moves the prompt on to the next player, or closes it.
+func (m *GameModel) nextName() {
+	m.entry.queue = m.entry.queue[1:]
+	if len(m.entry.queue) == 0 {
+		m.entry = nil
+		return
+	}
+	m.entry.name = defaultName()
+}
+
+// viewNameEntry renders the high-score prompt line.
+func (m GameModel) viewNameEntry() string {
+	who := ""
+	if len(m.game.Players) > 1 {
+		who = " for " + m.game.Players[m.entry.queue[0]].Name
+	}
+	prompt := lipgloss.NewStyle().Foreground(m.theme.Accent).Bold(true).
+		Render("  New high score" + who + "! name: ")
+	name := lipgloss.NewStyle().Foreground(m.theme.Foreground).Render(string(m.entry.name) + "_")
+	keys := lipgloss.NewStyle().Foreground(m.theme.Dim).Render("  [enter] save  [esc] skip")
+	return prompt + name + keys
+}
+
+// updateScores handles the High Scores screen: left and right flip
+// through the game modes.
+func (m MenuModel) updateScores(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	modes := len(game.Modes())
+	switch msg.String() {
+	case "left", "h":
+		m.scoreMode = game.Mode(cycle(int(m.scoreMode), modes, true))
+		return m, loadScoresCmd(m.scores, m.scoreKey())
+	case "right", "l":
+		m.scoreMode = game.Mode(cycle(int(m.scoreMode), modes, false))
+		return m, loadScoresCmd(m.scores, m.scoreKey())
+	case "esc", "q":
+		m.state = menuMain
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+// scoreKey returns the table shown on the High Scores screen.
+func (m MenuModel) scoreKey() scores.Key {
+	return scores.Key{Cluster: m.clusterName, Namespace: m.namespace, Mode: m.scoreMode.String()}
+}
+
+// updateLeaderboard handles the Leaderboard screen.
+func (m MenuModel) updateLeaderboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r":
+		return m, loadPlayersCmd(m.scores, m.clusterName)
+	case "esc", "q":
+		m.state = menuMain
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+func (m MenuModel) viewLeaderboard() string {
+	theme := m.theme
+
+	source := "local scores"
+	if m.options.Leaderboard != "" {
+		source = "shared via configmap " + m.options.Leaderboard + "/" + scores.ConfigMapName
+	}
+	header := lipgloss.NewStyle().
+		Foreground(theme.AccentSoft).
+		Bold(true).
+		Render("  Leaderboard  " + m.clusterName)
+	where := lipgloss.NewStyle().
+		Foreground(theme.Dim).
+		Render("  " + source)
+
+	var body string
+	switch {
+	case m.scoresErr != "":
+		body = lipgloss.NewStyle().Foreground(theme.Error).Render(m.scoresErr)
+	case len(m.standings) == 0:
+		body = lipgloss.NewStyle().Foreground(theme.Dim).Italic(true).Render("nobody has scored yet")
+	default:
+		rows := []string{fmt.Sprintf("    %-*s %5s  %5s  %5s", maxNameLength, "player", "best", "kills", "games")}
+		for i, s := range m.standings {
+			if i == scores.MaxEntries {
+				break
+			}
+			rows = append(rows, fmt.Sprintf("%2d. %-*s %5d  %5d  %5d", i+1, maxNameLength, s.Name, s.Best, s.Kills, s.Games))
+		}
+		body = lipgloss.NewStyle().Foreground(theme.Foreground).Render(strings.Join(rows, "\n"))
+	}
+
+	table := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Padding(1, 2).
+		Render(body)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		where,
+		"",
+		table,
+		"",
+		lipgloss.NewStyle().Foreground(theme.Dim).Render("  [r] refresh  [esc] back"),
+	)
+}
+
+func (m MenuModel) viewScores() string {
+	theme := m.theme
+
+	header := lipgloss.NewStyle().
+		Foreground(theme.AccentSoft).
+		Bold(true).
+		Render("  High Scores  < " + m.scoreMode.String() + " >")
+	where := lipgloss.NewStyle().
+		Foreground(theme.Dim).
+		Render("  " + m.scoreKey().String())
+
+	var body string
+	switch {
+	case m.scoresErr != "":
+		body = lipgloss.NewStyle().Foreground(theme.Error).Render(m.scoresErr)
+	case len(m.scoreTable) == 0:
+		body = lipgloss.NewStyle().Foreground(theme.Dim).Italic(true).Render("no scores yet")
+	default:
+		var rows []string
+		for i, e := range m.scoreTable {
+			rows = append(rows, fmt.Sprintf("%2d. %-*s %5d  %3d kills  %8s  %s",
+				i+1, maxNameLength, e.Name, e.Score, e.Kills, e.Duration, e.Date.Format("2006-01-02")))
+		}
+		body = lipgloss.NewStyle().Foreground(theme.Foreground).Render(strings.Join(rows, "\n"))
+	}
+
+	table := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Padding(1, 2).
+		Render(body)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		where,
+		"",
+		table,
+		"",
+		lipgloss.NewStyle().Foreground(theme.Dim).Render("  [h/l] mode  [esc] back"),
+	)
+}
@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kristinb/snakeinak8/internal/game"
+)
+
+// logDetailLines is how many lines the selected entry's details take up
+// below the list.
+const logDetailLines = 9
+
+// openLog shows the kill log panel, pausing a running game until it is
+// closed again.
+func (m *GameModel) openLog() {
+	m.logOpen = true
+	m.logTyping = false
+	m.logResume = m.game.State == game.StateRunning
+	if m.logResume {
+		m.game.TogglePause()
+	}
+	// Start on the latest entry.
+	m.logCursor = max(len(m.logEntries())-1, 0)
+	m.logScroll = max(m.logCursor-m.logPage()+1, 0)
+}
+
+// closeLog hides the kill log panel and resumes the game it paused. If the
+// terminal shrank meanwhile, the game stays paused until the board fits.
+func (m *GameModel) closeLog() {
+	m.logOpen = false
+	m.logTyping = false
+	if m.logResume && m.game.State == game.StatePaused {
+		if m.tooSmall() {
+			m.sizeResume = true
+		} else {
+			m.game.TogglePause()
+		}
+	}
+	m.logResume = false
+}
+
+// logEntries returns the kill log entries matching the filter.
+func (m GameModel) logEntries() []killEntry {
+	filter := strings.ToLower(string(m.logFilter))
+	if filter == "" {
+		return m.killLog
+	}
+	var entries []killEntry
+	for _, e := range m.killLog {
+		fields := []string{e.Result, e.Pod.Namespace, e.Pod.Name, e.Pod.Owner, e.Pod.Node, m.killer(e), e.Text}
+		if strings.Contains(strings.ToLower(strings.Join(fields, " ")), filter) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// killer names who an entry is credited to.
+func (m GameModel) killer(e killEntry) string {
+	if e.Player < 0 {
+		return "cluster"
+	}
+	return m.game.Players[e.Player].Name
+}
+
+// updateFullLog handles the kill log panel: moving the selection, typing a
+// filter and closing it.
+func (m GameModel) updateFullLog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.logTyping {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.logTyping = false
+		case tea.KeyEsc:
+			m.logTyping = false
+			m.logFilter = nil
+		case tea.KeyBackspace:
+			if len(m.logFilter) > 0 {
+				m.logFilter = m.logFilter[:len(m.logFilter)-1]
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.logFilter = append(m.logFilter, msg.Runes...)
+		}
+		m.logCursor, m.logScroll = 0, 0
+		return m, nil
+	}
+
+	page := m.logPage()
+	last := max(len(m.logEntries())-1, 0)
+	switch msg.String() {
+	case "up", "k":
+		m.logCursor = max(m.logCursor-1, 0)
+	case "down", "j":
+		m.logCursor = min(m.logCursor+1, last)
+	case "pgup":
+		m.logCursor = max(m.logCursor-page, 0)
+	case "pgdown":
+		m.logCursor = min(m.logCursor+page, last)
+	case "home", "g":
+		m.logCursor = 0
+	case "end", "G":
+		m.logCursor = last
+	case "/":
+		m.logTyping = true
+	case "esc", "l", "q":
+		if len(m.logFilter) > 0 && msg.String() == "esc" {
+			m.logFilter = nil
+			m.logCursor, m.logScroll = 0, 0
+			return m, nil
+		}
+		m.closeLog()
+		return m, nil
+	}
+	// Keep the selection on screen.
+	if m.logCursor < m.logScroll {
+		m.logScroll = m.logCursor
+	}
+	if m.logCursor >= m.logScroll+page {
+		m.logScroll = m.logCursor - page + 1
+	}
+	return m, nil
+}
+
+// logPage is how many kill log entries fit on screen above the details.
+func (m GameModel) logPage() int {
+	return max(m.height-7-logDetailLines, 1)
+}
+
+// viewFullLog renders the kill log panel: a page of entries with the
+// selected one highlighted, and the details of its pod below.
+func (m GameModel) viewFullLog() string {
+	theme := m.theme
+	entries := m.logEntries()
+	page := m.logPage()
+	end := min(m.logScroll+page, len(entries))
+
+	dim := lipgloss.NewStyle().Foreground(theme.Dim)
+	selected := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+
+	var lines []string
+	for i := m.logScroll; i < end; i++ {
+		e := entries[i]
+		line := fmt.Sprintf("%s  %s %-10s %-9s %-40s %s",
+			e.Time.Format("15:04:05"), resultMarkers[e.Result], e.Result, m.killer(e), e.Pod.Namespace+"/"+e.Pod.Name, e.Pod.Owner)
+		if i == m.logCursor {
+			lines = append(lines, selected.Render("> "+line))
+		} else {
+			lines = append(lines, theme.KillLogStyle.Render("  "+line))
+		}
+	}
+	if len(lines) == 0 {
+		empty := "  nothing happened"
+		if len(m.logFilter) > 0 {
+			empty = "  nothing matches"
+		}
+		lines = append(lines, dim.Italic(true).Render(empty))
+	}
+	for len(lines) < page {
+		lines = append(lines, "")
+	}
+
+	title := fmt.Sprintf("kill log  %d of %d", min(m.logCursor+1, len(entries)), len(entries))
+	if len(entries) != len(m.killLog) {
+		title += fmt.Sprintf(" (%d total)", len(m.killLog))
+	}
+	if m.logResume {
+		title += "  -- game paused"
+	}
+	filter := ""
+	if m.logTyping || len(m.logFilter) > 0 {
+		cursor := ""
+		if m.logTyping {
+			cursor = "_"
+		}
+		filter = dim.Render("  filter: ") + selected.Render(string(m.logFilter)+cursor)
+	}
+	header := theme.HeaderStyle.Render(title) + filter
+	columns := dim.Render(fmt.Sprintf("  %-8s  %-12s %-9s %-40s %s", "time", "result", "by", "pod", "owner"))
+
+	var details string
+	if m.logCursor < len(entries) {
+		details = m.viewLogEntry(entries[m.logCursor])
+	}
+
+	keys := "[j/k] select  [pgup/pgdown] page  [/] filter  [esc] back"
+	if m.logTyping {
+		keys = "[enter] apply filter  [esc] clear filter"
+	}
+	controls := theme.FooterStyle.Render(keys)
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", columns, strings.Join(lines, "\n"), "", details, controls)
+}
+
+// viewLogEntry renders the pod of a kill log entry as it was when it left
+// the board.
+func (m GameModel) viewLogEntry(e killEntry) string {
+	theme := m.theme
+	label := lipgloss.NewStyle().Foreground(theme.Dim)
+	value := lipgloss.NewStyle().Foreground(theme.Foreground)
+	row := func(name, v string) string {
+		if v == "" {
+			v = "-"
+		}
+		return label.Render(fmt.Sprintf("  %-10s", name)) + value.Render(v)
+	}
+
+	pod := e.Pod
+	age := "-"
+	if !pod.Created.IsZero() {
+		age = formatAge(e.Time.Sub(pod.Created)) + " when " + e.Result
+	}
+	rows := []string{
+		row("pod", pod.Namespace+"/"+pod.Name),
+		row("result", e.Text),
+		row("node", pod.Node),
+		row("owner", pod.Owner),
+		row("age", age),
+		row("restarts", fmt.Sprint(pod.Restarts)),
+		row("phase", pod.Phase.String()),
+		row("image", pod.Image),
+		row("labels", formatLabels(pod.Labels)),
+	}
+	for len(rows) < logDetailLines {
+		rows = append(rows, "")
+	}
+	return strings.Join(rows, "\n")
+}
+
+// formatLabels joins labels as k=v pairs in a stable order.
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// formatAge shortens a duration the way kubectl shows pod ages: the two
+// largest units, e.g. 3d4h, 2h5m or 45s.
+func formatAge(d time.Duration) string {
+	d = max(d, 0).Round(time.Second)
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%ds", minutes, seconds)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxLegendName caps namespace names so the legend stays narrow.
+const maxLegendName = 20
+
+// RenderLegend draws the namespace color key shown beside the board when
+// playing across all namespaces. At most maxRows namespaces are listed.
+func RenderLegend(theme Theme, namespaces []string, colors map[string]lipgloss.Color, maxRows int) string {
+	if len(namespaces) == 0 {
+		return ""
+	}
+
+	title := lipgloss.NewStyle().Foreground(theme.AccentSoft).Bold(true).Render("namespaces")
+	lines := []string{title}
+	for i, ns := range namespaces {
+		if maxRows > 0 && i >= maxRows-1 && len(namespaces) > maxRows {
+			more := len(namespaces) - i
+			lines = append(lines, lipgloss.NewStyle().Foreground(theme.Dim).Render(
+				fmt.Sprintf("+%d more", more)))
+			break
+		}
+		name := ns
+		if len([]rune(name)) > maxLegendName {
+			name = string([]rune(name)[:maxLegendName-1]) + "~"
+		}
+		swatch := lipgloss.NewStyle().Foreground(colors[ns]).Bold(true).Render(theme.Glyphs.Pod)
+		label := lipgloss.NewStyle().Foreground(theme.Foreground).Render(name)
+		lines = append(lines, swatch+" "+label)
+	}
+
+	return lipgloss.NewStyle().
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
@@ -8,7 +8,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/kristinb/snakeinak8/internal/game"
 	"github.com/kristinb/snakeinak8/internal/k8s"
+	"github.com/kristinb/snakeinak8/internal/scores"
 )
 
 // menuState tracks which screen the menu is on.
@@ -19,6 +21,11 @@ const (
 	menuNamespace
 	menuConnecting
 	menuError
+	menuOptions
+	menuScores
+	menuLeaderboard
+	menuThemes
+	menuSettings
 )
 
 // namespacesLoadedMsg carries the list of namespaces from the cluster.
@@ -27,6 +34,27 @@ type namespacesLoadedMsg struct {
 	err        error
 }
 
+// nodesLoadedMsg carries the cluster nodes needed for the node layout.
+type nodesLoadedMsg struct {
+	nodes []k8s.NodeInfo
+	err   error
+}
+
+// zonesLoadedMsg carries the namespaces needed for the namespace layout.
+type zonesLoadedMsg struct {
+	namespaces []string
+	err        error
+}
+
+// attractIdle is how long the main menu may sit untouched before the game
+// starts playing itself, when GameOptions.Attract is on.
+const attractIdle = 60 * time.Second
+
+// menuIdleMsg fires attractIdle after the key press it was scheduled for.
+type menuIdleMsg struct {
+	seq int
+}
+
 // k8sConnectedMsg signals the k8s client was successfully created.
 type k8sConnectedMsg struct {
 	client *k8s.Client
@@ -46,21 +74,54 @@ type MenuModel struct {
 	width          int
 	height         int
 	clusterName    string
+	options        GameOptions
+	attract        bool             // the next game is an idle-menu autopilot demo
+	idleSeq        int              // key presses seen, kept across games to tell stale idle timers apart
+	games          int              // games started, to number the next one
+	limiter        *k8s.KillLimiter // kept across autopilot games so restarts don't reset the cap
+	scores         scores.Store
+	scoreMode      game.Mode // table shown on the High Scores screen
+	scoreTable     []scores.Entry
+	standings      []scores.Standing
+	scoresErr      string
+	themes         []Theme     // listed on the Theme screen
+	themeErrs      []string    // theme files that failed to load
+	savedOptions   GameOptions // restored when the Settings screen is left without saving
+	settingsErr    string
 }
 
-// NewMenuModel creates the menu with the resolved kubeconfig path.
-func NewMenuModel(kubeconfigPath string) MenuModel {
+// NewMenuModel creates the menu with the resolved kubeconfig path and the
+// initial game options (usually taken from flags).
+func NewMenuModel(kubeconfigPath string, options GameOptions) MenuModel {
+	theme, err := LoadTheme(options.Theme)
+	if err != nil {
+		theme = DefaultTheme()
+	}
 	return MenuModel{
-		theme:          DefaultTheme(),
+		theme:          theme.ForTerminal(),
 		kubeconfigPath: kubeconfigPath,
 		namespace:      "",
 		state:          menuConnecting,
+		options:        options,
+		scores:         scores.NewFile(scores.DefaultPath()),
 	}
 }
 
+// WithNamespace returns the menu with the namespace to hunt in already
+// selected, empty for all namespaces.
+func (m MenuModel) WithNamespace(namespace string) MenuModel {
+	m.namespace = namespace
+	return m
+}
+
 // NewMenuModelFromGame rebuilds the menu from a running game, preserving
 // the k8s client, namespace selection, and terminal dimensions.
 func NewMenuModelFromGame(g GameModel) MenuModel {
+	options := g.options
+	if g.attract {
+		// The demo turned autopilot on for itself; put it back.
+		options.Autopilot = false
+	}
 	return MenuModel{
 		theme:          g.theme,
 		kubeconfigPath: g.kubeconfig,
@@ -69,6 +130,11 @@ func NewMenuModelFromGame(g GameModel) MenuModel {
 		clusterName:    g.clusterName,
 		width:          g.width,
 		height:         g.height,
+		options:        options,
+		limiter:        g.limiter,
+		scores:         g.scores,
+		idleSeq:        g.idleSeq + 1, // timers from before the game are stale
+		games:          g.number,
 		state:          menuMain,
 		cursor:         0,
 	}
@@ -88,18 +154,76 @@ func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
-			if m.state != menuNamespace {
+			if m.state == menuMain || m.state == menuConnecting || m.state == menuError {
 				return m, tea.Quit
 			}
 		}
 
+		m.idleSeq++
+		var next tea.Model = m
+		var cmd tea.Cmd
 		switch m.state {
 		case menuMain:
-			return m.updateMain(msg)
+			next, cmd = m.updateMain(msg)
 		case menuNamespace:
-			return m.updateNamespace(msg)
+			next, cmd = m.updateNamespace(msg)
 		case menuError:
-			return m.updateError(msg)
+			next, cmd = m.updateError(msg)
+		case menuOptions:
+			next, cmd = m.updateOptions(msg)
+		case menuScores:
+			next, cmd = m.updateScores(msg)
+		case menuLeaderboard:
+			next, cmd = m.updateLeaderboard(msg)
+		case menuThemes:
+			next, cmd = m.updateThemes(msg)
+		case menuSettings:
+			next, cmd = m.updateSettings(msg)
+		}
+		if menu, ok := next.(MenuModel); ok {
+			return menu, tea.Batch(cmd, menu.waitIdle())
+		}
+		return next, cmd
+
+	case scoresLoadedMsg:
+		if msg.key != m.scoreKey() {
+			return m, nil // stale: the mode changed while loading
+		}
+		m.scoreTable = msg.entries
+		m.scoresErr = ""
+		if msg.err != nil {
+			m.scoresErr = msg.err.Error()
+		}
+		return m, nil
+
+	case configSavedMsg:
+		if msg.err != nil {
+			m.state = menuSettings
+			m.cursor = 0
+			m.savedOptions = m.options
+			m.settingsErr = msg.err.Error()
+		}
+		return m, nil
+
+	case themesLoadedMsg:
+		m.themes = msg.themes
+		m.themeErrs = nil
+		for _, err := range msg.errs {
+			m.themeErrs = append(m.themeErrs, err.Error())
+		}
+		return m, nil
+
+	case playersLoadedMsg:
+		m.standings = msg.players
+		m.scoresErr = ""
+		if msg.err != nil {
+			m.scoresErr = msg.err.Error()
+		}
+		return m, nil
+
+	case menuIdleMsg:
+		if msg.seq == m.idleSeq && m.options.Attract && m.state == menuMain && m.k8sClient != nil {
+			return m.start(true)
 		}
 
 	case k8sConnectedMsg:
@@ -110,9 +234,15 @@ func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.k8sClient = msg.client
 		m.clusterName = msg.client.ClusterName()
+		if m.options.Leaderboard != "" {
+			m.scores = scores.NewConfigMap(msg.client.Clientset(), m.options.Leaderboard)
+		}
 		m.state = menuMain
 		m.cursor = 0
-		return m, nil
+		if m.options.Autopilot {
+			return m.start(false)
+		}
+		return m, m.waitIdle()
 
 	case namespacesLoadedMsg:
 		if msg.err != nil {
@@ -124,12 +254,35 @@ func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = menuNamespace
 		m.cursor = 0
 		return m, nil
+
+	case nodesLoadedMsg:
+		if msg.err != nil {
+			m.state = menuError
+			m.errMsg = msg.err.Error()
+			return m, nil
+		}
+		gameModel := m.newGame()
+		gameModel.game.SetTopology(toGameNodes(msg.nodes))
+		return gameModel, gameModel.Init()
+
+	case zonesLoadedMsg:
+		if msg.err != nil {
+			m.state = menuError
+			m.errMsg = msg.err.Error()
+			return m, nil
+		}
+		gameModel := m.newGame()
+		gameModel.game.SetZones(msg.namespaces)
+		for _, ns := range msg.namespaces {
+			gameModel.trackNamespace(ns)
+		}
+		return gameModel, gameModel.Init()
 	}
 
 	return m, nil
 }
 
-var mainMenuItems = []string{"Start Game", "Select Namespace", "Exit"}
+var mainMenuItems = []string{"Start Game", "Select Namespace", "Options", "Settings", "High Scores", "Leaderboard", "Theme", "Exit"}
 
 func (m MenuModel) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -144,18 +297,111 @@ func (m MenuModel) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		switch m.cursor {
 		case 0: // Start Game
-			m.k8sClient.SetNamespace(m.namespace)
-			gameModel := NewGameModel(m.k8sClient, m.namespace, m.theme, m.width, m.height, m.kubeconfigPath)
-			return gameModel, gameModel.Init()
+			return m.start(false)
 		case 1: // Select Namespace
-			return m, fetchNamespacesCmd(m.k8sClient)
-		case 2: // Exit
+			return m, fetchNamespacesCmd(m.k8sClient, m.options.ClusterTimeout)
+		case 2: // Options
+			m.state = menuOptions
+			m.cursor = 0
+		case 3: // Settings
+			m.state = menuSettings
+			m.cursor = 0
+			m.savedOptions = m.options
+			m.settingsErr = ""
+		case 4: // High Scores
+			m.state = menuScores
+			m.scoreMode = m.options.Mode
+			m.scoreTable = nil
+			m.scoresErr = ""
+			return m, loadScoresCmd(m.scores, m.scoreKey())
+		case 5: // Leaderboard
+			m.state = menuLeaderboard
+			m.standings = nil
+			m.scoresErr = ""
+			return m, loadPlayersCmd(m.scores, m.clusterName)
+		case 6: // Theme
+			m.state = menuThemes
+			m.cursor = 0
+			return m, loadThemesCmd()
+		case 7: // Exit
 			return m, tea.Quit
 		}
 	}
 	return m, nil
 }
 
+// start begins a game with the current options, loading the cluster
+// topology first when the layout needs it. An attract game is the demo the
+// idle menu starts: it plays on autopilot until a key is pressed.
+func (m MenuModel) start(attract bool) (tea.Model, tea.Cmd) {
+	if err := m.options.Validate(m.width, m.height, m.namespace == ""); err != nil {
+		m.state = menuSettings
+		m.cursor = 0
+		m.savedOptions = m.options
+		m.settingsErr = err.Error()
+		return m, m.waitIdle()
+	}
+	m.attract = attract
+	if attract {
+		m.options.Autopilot = true
+	}
+	m.k8sClient.SetNamespace(m.namespace)
+	m.k8sClient.SetAllPhases(m.options.AllPhases)
+	switch m.options.Layout {
+	case game.LayoutNodes:
+		return m, fetchNodesCmd(m.k8sClient, m.options.ClusterTimeout)
+	case game.LayoutNamespaces:
+		return m, fetchZonesCmd(m.k8sClient, m.options.ClusterTimeout)
+	}
+	gameModel := m.newGame()
+	return gameModel, gameModel.Init()
+}
+
+func (m MenuModel) newGame() GameModel {
+	g := NewGameModel(m.k8sClient, m.namespace, m.theme, m.options, m.width, m.height, m.kubeconfigPath)
+	g.attract = m.attract
+	g.idleSeq = m.idleSeq
+	g.number = m.games + 1
+	g.scores = m.scores
+	if g.limiter != nil && m.limiter != nil {
+		g.limiter = m.limiter
+	}
+	return g
+}
+
+// waitIdle reports back once the menu has gone attractIdle without another
+// key press. Nothing is scheduled unless attract mode is on.
+func (m MenuModel) waitIdle() tea.Cmd {
+	if !m.options.Attract {
+		return nil
+	}
+	seq := m.idleSeq
+	return tea.Tick(attractIdle, func(time.Time) tea.Msg {
+		return menuIdleMsg{seq: seq}
+	})
+}
+
+func (m MenuModel) updateOptions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(optionRows)-1 {
+			m.cursor++
+		}
+	case "enter", "right", "l":
+		optionRows[m.cursor].next(&m.options, false)
+	case "left", "h":
+		optionRows[m.cursor].next(&m.options, true)
+	case "esc", "q":
+		m.state = menuMain
+		m.cursor = 0
+	}
+	return m, nil
+}
+
 func (m MenuModel) updateNamespace(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Item 0 is "all namespaces", then the actual namespaces follow
 	totalItems := len(m.namespaces) + 1
@@ -244,6 +490,21 @@ func (m MenuModel) View() string {
 
 	case menuNamespace:
 		body = m.viewNamespaceMenu()
+
+	case menuOptions:
+		body = m.viewOptionsMenu()
+
+	case menuScores:
+		body = m.viewScores()
+
+	case menuLeaderboard:
+		body = m.viewLeaderboard()
+
+	case menuThemes:
+		body = m.viewThemes()
+
+	case menuSettings:
+		body = m.viewSettings()
 	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left,
@@ -285,7 +546,7 @@ func (m MenuModel) viewMainMenu() string {
 			label := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render(item)
 			items = append(items, "  "+cursor+label)
 		} else {
-			label := lipgloss.NewStyle().Foreground(theme.Foreground).Render("  "+item)
+			label := lipgloss.NewStyle().Foreground(theme.Foreground).Render("  " + item)
 			items = append(items, "  "+label)
 		}
 	}
@@ -372,6 +633,41 @@ func (m MenuModel) viewNamespaceMenu() string {
 	)
 }
 
+func (m MenuModel) viewOptionsMenu() string {
+	theme := m.theme
+
+	header := lipgloss.NewStyle().
+		Foreground(theme.AccentSoft).
+		Bold(true).
+		Render("  Options")
+
+	var items []string
+	for i, row := range optionRows {
+		line := fmt.Sprintf("%-12s < %s >", row.label, row.value(m.options))
+		if i == m.cursor {
+			cursor := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render("> ")
+			label := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render(line)
+			items = append(items, cursor+label)
+		} else {
+			items = append(items, "  "+lipgloss.NewStyle().Foreground(theme.Foreground).Render(line))
+		}
+	}
+
+	menu := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Padding(1, 2).
+		Render(strings.Join(items, "\n"))
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		"",
+		menu,
+		"",
+		lipgloss.NewStyle().Foreground(theme.Dim).Render("  [j/k] navigate  [h/l] change  [esc] back"),
+	)
+}
+
 func connectK8sCmd(kubeconfigPath string) tea.Cmd {
 	return func() tea.Msg {
 		client, err := k8s.NewClient(kubeconfigPath, "")
@@ -379,11 +675,39 @@ func connectK8sCmd(kubeconfigPath string) tea.Cmd {
 	}
 }
 
-func fetchNamespacesCmd(client *k8s.Client) tea.Cmd {
+func fetchNamespacesCmd(client *k8s.Client, timeout time.Duration) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 		ns, err := client.ListNamespaces(ctx)
 		return namespacesLoadedMsg{namespaces: ns, err: err}
 	}
 }
+
+func fetchNodesCmd(client *k8s.Client, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		nodes, err := client.ListNodes(ctx)
+		return nodesLoadedMsg{nodes: nodes, err: err}
+	}
+}
+
+func fetchZonesCmd(client *k8s.Client, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		ns, err := client.PodNamespaces(ctx)
+		return zonesLoadedMsg{namespaces: ns, err: err}
+	}
+}
+
+// toGameNodes converts cluster nodes into game rooms. Cordoned and
+// NotReady nodes become hazards.
+func toGameNodes(nodes []k8s.NodeInfo) []game.Node {
+	out := make([]game.Node, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, game.Node{Name: n.Name, Hazard: n.Unschedulable || !n.Ready})
+	}
+	return out
+}
@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kristinb/snakeinak8/internal/game"
+)
+
+// GameOptions holds the gameplay choices made in the menu or via flags.
+type GameOptions struct {
+	Mode       game.Mode
+	Layout     game.Layout
+	Difficulty game.Difficulty
+	PowerUps   bool // spawn ConfigMaps, Services, Secrets and PVCs as power-ups
+	Decoys     game.DecoyRule
+	Movement   game.PodMovement
+	AllPhases  bool          // also show Pending, crash-looping and terminating pods
+	Players    int           // snakes sharing the keyboard: WASD for P1, arrows for P2
+	Bot        game.Strategy // computer-controlled opponent, nil for none
+	Autopilot  bool          // a pathfinding bot steers player one
+	KillRate   int           // most pods autopilot may kill per minute, 0 for no cap
+	Attract    bool          // an idle main menu starts an autopilot demo
+	BoardSize  BoardSize     // fixed board size, zero to fit the terminal
+	Cells      CellMode      // how board cells are drawn
+	Theme      string        // theme name, empty for the default
+	TickRate   time.Duration // starting tick rate, zero for the difficulty's
+	MaxPods    int           // pods on the board, zero for the difficulty's
+	// FetchTimeout bounds each pod and power-up fetch and each kill;
+	// ClusterTimeout bounds listing namespaces and nodes.
+	FetchTimeout   time.Duration
+	ClusterTimeout time.Duration
+	// AutopilotStrategy steers player one on autopilot, nil for the
+	// shortest path.
+	AutopilotStrategy game.Strategy
+	// DistinctGlyphs draws every game element with a character of its
+	// own, so the board reads without color.
+	DistinctGlyphs bool
+	// Leaderboard is the namespace of the ConfigMap holding the cluster's
+	// shared high scores. Empty keeps scores in a local file.
+	Leaderboard string
+}
+
+// NoColor reports whether the NO_COLOR convention (https://no-color.org)
+// asks for output without color.
+func NoColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// snakes returns how many snakes a game with these options starts with:
+// one per local player and one for the computer opponent.
+func (o GameOptions) snakes() int {
+	n := max(o.Players, 1)
+	if o.Bot != nil {
+		n++
+	}
+	return n
+}
+
+// cellMode returns how board cells are drawn. Half blocks tell things apart
+// by color alone, so with distinct glyphs (and so without color) the board
+// falls back to wide cells, which are square too.
+func (o GameOptions) cellMode() CellMode {
+	if o.Cells == CellsHalf && o.DistinctGlyphs {
+		return CellsWide
+	}
+	return o.Cells
+}
+
+// MaxLocalPlayers is how many humans fit on one keyboard.
+const MaxLocalPlayers = 2
+
+// DefaultGameOptions returns the options used when no flags are given.
+func DefaultGameOptions() GameOptions {
+	return GameOptions{
+		Layout:         game.LayoutRandom,
+		Difficulty:     game.Normal,
+		Players:        1,
+		KillRate:       6,
+		FetchTimeout:   5 * time.Second,
+		ClusterTimeout: 10 * time.Second,
+	}
+}
+
+// optionRow is one line of the options screen. next cycles the value
+// forward (or backward when reverse is set).
+type optionRow struct {
+	label string
+	value func(o GameOptions) string
+	next  func(o *GameOptions, reverse bool)
+}
+
+var optionRows = []optionRow{
+	{
+		label: "Mode",
+		value: func(o GameOptions) string { return o.Mode.String() },
+		next: func(o *GameOptions, reverse bool) {
+			o.Mode = game.Mode(cycle(int(o.Mode), len(game.Modes()), reverse))
+		},
+	},
+	{
+		label: "Layout",
+		value: func(o GameOptions) string { return o.Layout.String() },
+		next: func(o *GameOptions, reverse bool) {
+			o.Layout = game.Layout(cycle(int(o.Layout), 3, reverse))
+		},
+	},
+	{
+		label: "Difficulty",
+		value: func(o GameOptions) string { return o.Difficulty.Name },
+		next: func(o *GameOptions, reverse bool) {
+			presets := game.Difficulties()
+			i := 0
+			for j, d := range presets {
+				if d.Name == o.Difficulty.Name {
+					i = j
+				}
+			}
+			o.Difficulty = presets[cycle(i, len(presets), reverse)]
+		},
+	},
+	{
+		label: "Power-ups",
+		value: func(o GameOptions) string { return onOff(o.PowerUps) },
+		next:  func(o *GameOptions, _ bool) { o.PowerUps = !o.PowerUps },
+	},
+	{
+		label: "Decoys",
+		value: func(o GameOptions) string { return o.Decoys.String() },
+		next: func(o *GameOptions, reverse bool) {
+			o.Decoys = game.DecoyRule(cycle(int(o.Decoys), 3, reverse))
+		},
+	},
+	{
+		label: "Pod moves",
+		value: func(o GameOptions) string { return o.Movement.String() },
+		next: func(o *GameOptions, reverse bool) {
+			o.Movement = game.PodMovement(cycle(int(o.Movement), 3, reverse))
+		},
+	},
+	{
+		label: "Pod phases",
+		value: func(o GameOptions) string {
+			if o.AllPhases {
+				return "all"
+			}
+			return "running"
+		},
+		next: func(o *GameOptions, _ bool) { o.AllPhases = !o.AllPhases },
+	},
+	{
+		label: "Cells",
+		value: func(o GameOptions) string {
+			if o.cellMode() != o.Cells {
+				return o.Cells.String() + " (" + o.cellMode().String() + " with distinct glyphs)"
+			}
+			return o.Cells.String()
+		},
+		next: func(o *GameOptions, reverse bool) {
+			o.Cells = CellMode(cycle(int(o.Cells), len(CellModes()), reverse))
+		},
+	},
+	{
+		label: "Glyphs",
+		value: func(o GameOptions) string {
+			if o.DistinctGlyphs {
+				return "distinct"
+			}
+			return "theme"
+		},
+		next: func(o *GameOptions, _ bool) { o.DistinctGlyphs = !o.DistinctGlyphs },
+	},
+	{
+		label: "Players",
+		value: func(o GameOptions) string { return strconv.Itoa(o.Players) },
+		next: func(o *GameOptions, reverse bool) {
+			o.Players = cycle(o.Players-1, MaxLocalPlayers, reverse) + 1
+		},
+	},
+	{
+		label: "AI snake",
+		value: func(o GameOptions) string { return botName(o.Bot) },
+		next: func(o *GameOptions, reverse bool) {
+			// Index 0 is "off", then the strategies from easiest to hardest.
+			choices := append([]game.Strategy{nil}, game.Strategies()...)
+			i := 0
+			for j, s := range choices {
+				if s == o.Bot {
+					i = j
+				}
+			}
+			o.Bot = choices[cycle(i, len(choices), reverse)]
+		},
+	},
+	{
+		label: "Autopilot",
+		value: func(o GameOptions) string { return onOff(o.Autopilot) },
+		next:  func(o *GameOptions, _ bool) { o.Autopilot = !o.Autopilot },
+	},
+}
+
+// botName describes the AI opponent setting.
+func botName(s game.Strategy) string {
+	if s == nil {
+		return "off"
+	}
+	if level := game.OpponentLevel(s); level != "" {
+		return level + " (" + s.Name() + ")"
+	}
+	return s.Name()
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// cycle steps i through [0, n), wrapping at either end.
+func cycle(i, n int, reverse bool) int {
+	if reverse {
+		return (i + n - 1) % n
+	}
+	return (i + 1) % n
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kristinb/snakeinak8/internal/game"
+)
+
+// podInfoWidth is the width of the pod info panel's values; longer names
+// are cut short.
+const podInfoWidth = 24
+
+// RenderPodInfo draws the side panel describing a pod on the board: the
+// one nearest the snake's head, or the one under the cursor while paused.
+// A nil pod renders the title with a placeholder.
+func RenderPodInfo(theme Theme, title string, pod *game.Pod, now time.Time) string {
+	lines := []string{lipgloss.NewStyle().Foreground(theme.AccentSoft).Bold(true).Render(title)}
+	if pod == nil {
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.Dim).Italic(true).Render("no pod here"))
+		return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+	}
+
+	label := lipgloss.NewStyle().Foreground(theme.Dim)
+	value := lipgloss.NewStyle().Foreground(theme.Foreground)
+	row := func(name, v string) string {
+		if v == "" {
+			v = "-"
+		}
+		if r := []rune(v); len(r) > podInfoWidth {
+			v = string(r[:podInfoWidth-1]) + "~"
+		}
+		return label.Render(fmt.Sprintf("%-9s", name)) + value.Render(v)
+	}
+
+	age := "-"
+	if !pod.Created.IsZero() {
+		age = formatAge(now.Sub(pod.Created))
+	}
+	lines = append(lines,
+		row("name", pod.Name),
+		row("ns", pod.Namespace),
+		row("node", pod.Node),
+		row("age", age),
+		row("phase", pod.Phase.String()),
+		row("restarts", fmt.Sprint(pod.Restarts)),
+		row("owner", pod.Owner),
+		row("image", pod.Image),
+	)
+	if pod.Protected {
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.HazardColor).Bold(true).Render("protected -- decoy"))
+	}
+	return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
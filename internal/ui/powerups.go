@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kristinb/snakeinak8/internal/game"
+	"github.com/kristinb/snakeinak8/internal/k8s"
+)
+
+// itemSpawnTicks is how many ticks to wait between power-up fetches.
+const itemSpawnTicks = 40
+
+// itemPlacedMsg signals a cluster resource was fetched to use as a power-up.
+type itemPlacedMsg struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Err       error
+}
+
+// powerUpFor maps a resource kind to the power-up it grants.
+func powerUpFor(kind string) game.PowerUp {
+	switch kind {
+	case k8s.KindService:
+		return game.PowerPhase
+	case k8s.KindSecret:
+		return game.PowerDoublePoints
+	case k8s.KindPVC:
+		return game.PowerShrink
+	default:
+		return game.PowerInvincible
+	}
+}
+
+func fetchItemCmd(client *k8s.Client, timeout time.Duration) tea.Cmd {
+	kind := k8s.PowerUpKinds[rand.Intn(len(k8s.PowerUpKinds))]
+	return func() tea.Msg {
+		if client == nil {
+			return itemPlacedMsg{}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		res, err := client.RandomResource(ctx, kind)
+		if err != nil {
+			return itemPlacedMsg{Err: err}
+		}
+		if res == nil {
+			return itemPlacedMsg{}
+		}
+		return itemPlacedMsg{Kind: res.Kind, Name: res.Name, Namespace: res.Namespace}
+	}
+}
+
+// renderEffects lists the active power-ups and their remaining ticks.
+func renderEffects(theme Theme, label string, effects map[game.PowerUp]int) string {
+	if len(effects) == 0 {
+		return ""
+	}
+	active := make([]game.PowerUp, 0, len(effects))
+	for p := range effects {
+		active = append(active, p)
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i] < active[j] })
+
+	parts := make([]string, 0, len(active))
+	for _, p := range active {
+		parts = append(parts, fmt.Sprintf("%s %d", p, effects[p]))
+	}
+	return lipgloss.NewStyle().
+		Foreground(theme.PowerUpColor).
+		Render("  " + label + ": " + strings.Join(parts, "  "))
+}
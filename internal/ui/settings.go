@@ -0,0 +1,311 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kristinb/snakeinak8/internal/game"
+	"sigs.k8s.io/yaml"
+)
+
+// Bounds of the gameplay settings. A zero tick rate or pod count keeps the
+// difficulty preset's.
+const (
+	minTickRate  = 30 * time.Millisecond
+	maxTickRate  = 500 * time.Millisecond
+	tickRateStep = 10 * time.Millisecond
+	maxPodsLimit = 200
+	minTimeout   = time.Second
+	maxTimeout   = 2 * time.Minute
+)
+
+// difficulty returns the preset with the tick rate and pod count settings
+// applied.
+func (o GameOptions) difficulty() game.Difficulty {
+	return o.Difficulty.Tuned(o.TickRate, o.MaxPods)
+}
+
+// Validate checks the settings against their bounds and each other. Auto
+// board sizes are checked against the board a terminal of the given size
+// would get; a zero size stands for the default board.
+func (o GameOptions) Validate(width, height int, allNamespaces bool) error {
+	if o.TickRate != 0 && (o.TickRate < minTickRate || o.TickRate > maxTickRate) {
+		return fmt.Errorf("tick rate must be between %s and %s", minTickRate, maxTickRate)
+	}
+	if o.MaxPods < 0 || o.MaxPods > maxPodsLimit {
+		return fmt.Errorf("max pods must be between 1 and %d (0 keeps the preset)", maxPodsLimit)
+	}
+	if o.FetchTimeout < minTimeout || o.FetchTimeout > maxTimeout {
+		return fmt.Errorf("fetch timeout must be between %s and %s", minTimeout, maxTimeout)
+	}
+	if o.ClusterTimeout < minTimeout || o.ClusterTimeout > maxTimeout {
+		return fmt.Errorf("cluster timeout must be between %s and %s", minTimeout, maxTimeout)
+	}
+
+	// Pods, decoys and power-ups all need a free cell, and the snakes
+	// need somewhere to go.
+	g := newGame(o, width, height, allNamespaces)
+	free := g.Board.OpenCells()
+	for _, p := range g.Players {
+		free -= len(p.Snake.Body)
+	}
+	need := g.Difficulty.MaxPodsCap
+	if o.Decoys != game.DecoysOff {
+		need += g.MaxDecoys
+	}
+	if o.PowerUps {
+		need += g.MaxItems
+	}
+	if need >= free {
+		return fmt.Errorf("%d pods and items do not fit on a %dx%d board with %d free cells",
+			need, g.Board.Width, g.Board.Height, free)
+	}
+	return nil
+}
+
+// Config is the settings file, config.yaml in ConfigDir. Flags override
+// what it sets.
+type Config struct {
+	TickRate       string `json:"tick-rate,omitempty"`
+	BoardSize      string `json:"board-size,omitempty"`
+	MaxPods        int    `json:"max-pods,omitempty"`
+	FetchTimeout   string `json:"fetch-timeout,omitempty"`
+	ClusterTimeout string `json:"cluster-timeout,omitempty"`
+	Theme          string `json:"theme,omitempty"`
+}
+
+// ConfigPath returns the settings file's path.
+func ConfigPath() string {
+	return filepath.Join(ConfigDir(), "config.yaml")
+}
+
+// LoadConfig applies the settings file at path to o. A missing file
+// changes nothing.
+func LoadConfig(path string, o *GameOptions) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	var c Config
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return fmt.Errorf("config %s: %w", path, err)
+	}
+
+	duration := func(s string, d *time.Duration) error {
+		if s == "" {
+			return nil
+		}
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("config %s: %w", path, err)
+		}
+		*d = v
+		return nil
+	}
+	if err := duration(c.TickRate, &o.TickRate); err != nil {
+		return err
+	}
+	if err := duration(c.FetchTimeout, &o.FetchTimeout); err != nil {
+		return err
+	}
+	if err := duration(c.ClusterTimeout, &o.ClusterTimeout); err != nil {
+		return err
+	}
+	if c.BoardSize != "" {
+		size, err := ParseBoardSize(c.BoardSize)
+		if err != nil {
+			return fmt.Errorf("config %s: %w", path, err)
+		}
+		o.BoardSize = size
+	}
+	if c.Theme != "" {
+		if _, err := LoadTheme(c.Theme); err != nil {
+			return fmt.Errorf("config %s: %w", path, err)
+		}
+		o.Theme = c.Theme
+	}
+	o.MaxPods = c.MaxPods
+	return nil
+}
+
+// SaveConfig writes the settings in o to path.
+func SaveConfig(path string, o GameOptions) error {
+	c := Config{
+		BoardSize:      o.BoardSize.String(),
+		MaxPods:        o.MaxPods,
+		FetchTimeout:   o.FetchTimeout.String(),
+		ClusterTimeout: o.ClusterTimeout.String(),
+		Theme:          o.Theme,
+	}
+	if o.TickRate > 0 {
+		c.TickRate = o.TickRate.String()
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// configSavedMsg reports whether the settings were written.
+type configSavedMsg struct {
+	err error
+}
+
+func saveConfigCmd(o GameOptions) tea.Cmd {
+	return func() tea.Msg {
+		return configSavedMsg{err: SaveConfig(ConfigPath(), o)}
+	}
+}
+
+// stepSetting moves v by step within [lo, hi]. Zero stands for the preset
+// and sits just below lo.
+func stepSetting[T int | time.Duration](v, step, lo, hi T, reverse bool) T {
+	switch {
+	case reverse && v <= lo:
+		return 0
+	case reverse:
+		return v - step
+	case v == 0:
+		return lo
+	}
+	return min(v+step, hi)
+}
+
+// presetOr shows a setting, or that the difficulty preset decides it.
+func presetOr[T int | time.Duration](v T) string {
+	if v == 0 {
+		return "preset"
+	}
+	return fmt.Sprint(v)
+}
+
+var settingRows = []optionRow{
+	{
+		label: "Tick rate",
+		value: func(o GameOptions) string { return presetOr(o.TickRate) },
+		next: func(o *GameOptions, reverse bool) {
+			o.TickRate = stepSetting(o.TickRate, tickRateStep, minTickRate, maxTickRate, reverse)
+		},
+	},
+	{
+		label: "Board size",
+		value: func(o GameOptions) string { return o.BoardSize.String() },
+		next: func(o *GameOptions, reverse bool) {
+			i := 0
+			for j, s := range boardSizes {
+				if s == o.BoardSize {
+					i = j
+				}
+			}
+			o.BoardSize = boardSizes[cycle(i, len(boardSizes), reverse)]
+		},
+	},
+	{
+		label: "Max pods",
+		value: func(o GameOptions) string { return presetOr(o.MaxPods) },
+		next: func(o *GameOptions, reverse bool) {
+			o.MaxPods = stepSetting(o.MaxPods, 1, 1, maxPodsLimit, reverse)
+		},
+	},
+	{
+		label: "Fetch timeout",
+		value: func(o GameOptions) string { return o.FetchTimeout.String() },
+		next: func(o *GameOptions, reverse bool) {
+			o.FetchTimeout = max(stepSetting(o.FetchTimeout, time.Second, minTimeout, maxTimeout, reverse), minTimeout)
+		},
+	},
+	{
+		label: "Cluster timeout",
+		value: func(o GameOptions) string { return o.ClusterTimeout.String() },
+		next: func(o *GameOptions, reverse bool) {
+			o.ClusterTimeout = max(stepSetting(o.ClusterTimeout, time.Second, minTimeout, maxTimeout, reverse), minTimeout)
+		},
+	},
+}
+
+// updateSettings handles the Settings screen. Changes are checked and
+// saved with enter; esc throws them away.
+func (m MenuModel) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(settingRows)-1 {
+			m.cursor++
+		}
+	case "right", "l":
+		settingRows[m.cursor].next(&m.options, false)
+		m.settingsErr = ""
+	case "left", "h":
+		settingRows[m.cursor].next(&m.options, true)
+		m.settingsErr = ""
+	case "enter", "s":
+		if err := m.options.Validate(m.width, m.height, m.namespace == ""); err != nil {
+			m.settingsErr = err.Error()
+			return m, nil
+		}
+		m.state = menuMain
+		m.cursor = 0
+		return m, saveConfigCmd(m.options)
+	case "esc", "q":
+		m.options = m.savedOptions
+		m.settingsErr = ""
+		m.state = menuMain
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+func (m MenuModel) viewSettings() string {
+	theme := m.theme
+
+	header := lipgloss.NewStyle().
+		Foreground(theme.AccentSoft).
+		Bold(true).
+		Render("  Settings")
+	where := lipgloss.NewStyle().
+		Foreground(theme.Dim).
+		Render("  saved to " + ConfigPath())
+
+	var rows []string
+	for i, row := range settingRows {
+		label := fmt.Sprintf("%-16s", row.label)
+		value := "< " + row.value(m.options) + " >"
+		if i == m.cursor {
+			cursor := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render("> ")
+			rows = append(rows, cursor+lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render(label+value))
+		} else {
+			rows = append(rows, "  "+lipgloss.NewStyle().Foreground(theme.Foreground).Render(label+value))
+		}
+	}
+	list := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	parts := []string{header, where, "", list}
+	if m.settingsErr != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Error).Render("  "+m.settingsErr))
+	}
+	parts = append(parts, "", lipgloss.NewStyle().Foreground(theme.Dim).Render("  [j/k] navigate  [h/l] change  [enter] save  [esc] discard"))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kristinb/snakeinak8/internal/game"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(o *GameOptions)
+		wantErr string
+	}{
+		{name: "defaults", change: func(o *GameOptions) {}},
+		{name: "tuned", change: func(o *GameOptions) { o.TickRate, o.MaxPods = 80*time.Millisecond, 50 }},
+		{name: "tick rate too fast", change: func(o *GameOptions) { o.TickRate = 10 * time.Millisecond }, wantErr: "tick rate"},
+		{name: "tick rate too slow", change: func(o *GameOptions) { o.TickRate = time.Second }, wantErr: "tick rate"},
+		{name: "negative pods", change: func(o *GameOptions) { o.MaxPods = -1 }, wantErr: "max pods"},
+		{name: "too many pods", change: func(o *GameOptions) { o.MaxPods = maxPodsLimit + 1 }, wantErr: "max pods"},
+		{name: "no fetch timeout", change: func(o *GameOptions) { o.FetchTimeout = 0 }, wantErr: "fetch timeout"},
+		{name: "cluster timeout too long", change: func(o *GameOptions) { o.ClusterTimeout = time.Hour }, wantErr: "cluster timeout"},
+		{
+			name:    "more pods than free cells",
+			change:  func(o *GameOptions) { o.BoardSize, o.MaxPods = BoardSize{20, 10}, maxPodsLimit },
+			wantErr: "do not fit on a 20x10 board",
+		},
+		{
+			name: "decoys and power-ups need room too",
+			change: func(o *GameOptions) {
+				o.BoardSize, o.MaxPods = BoardSize{20, 10}, 194
+				o.Decoys, o.PowerUps = game.DecoysEndGame, true
+			},
+			wantErr: "do not fit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := DefaultGameOptions()
+			tt.change(&o)
+			err := o.Validate(0, 0, true)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snakeinak8", "config.yaml")
+	o := DefaultGameOptions()
+	o.TickRate = 90 * time.Millisecond
+	o.BoardSize = BoardSize{60, 25}
+	o.MaxPods = 12
+	o.FetchTimeout = 3 * time.Second
+	o.ClusterTimeout = 20 * time.Second
+	o.Theme = "solarized"
+	if err := SaveConfig(path, o); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := DefaultGameOptions()
+	if err := LoadConfig(path, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if loaded != o {
+		t.Fatalf("expected %+v, got %+v", o, loaded)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	o := DefaultGameOptions()
+	if err := LoadConfig(filepath.Join(dir, "missing.yaml"), &o); err != nil || o != DefaultGameOptions() {
+		t.Fatalf("expected a missing file to change nothing, got %+v (%v)", o, err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "unknown key", data: "tick: 80ms\n", wantErr: "unknown field"},
+		{name: "bad duration", data: "fetch-timeout: soon\n", wantErr: "invalid duration"},
+		{name: "bad board size", data: "board-size: huge\n", wantErr: "invalid board size"},
+		{name: "unknown theme", data: "theme: neon\n", wantErr: "neon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			o := DefaultGameOptions()
+			if err := LoadConfig(path, &o); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStepSetting(t *testing.T) {
+	tests := []struct {
+		v, want int
+		reverse bool
+	}{
+		{v: 0, want: 1}, // preset, then the lowest value
+		{v: 1, want: 2},
+		{v: 10, want: 10}, // stops at the top
+		{v: 5, want: 4, reverse: true},
+		{v: 1, want: 0, reverse: true}, // back to the preset
+		{v: 0, want: 0, reverse: true}, // and no further
+	}
+	for _, tt := range tests {
+		if got := stepSetting(tt.v, 1, 1, 10, tt.reverse); got != tt.want {
+			t.Errorf("stepSetting(%d, reverse=%v) = %d, want %d", tt.v, tt.reverse, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Board size bounds. An auto-sized board fills the terminal within these,
+// and a fixed size must lie within them too.
+const (
+	minBoardWidth  = 20
+	minBoardHeight = 10
+	maxBoardWidth  = 100
+	maxBoardHeight = 40
+
+	// Used when the terminal size is not known yet.
+	defaultBoardWidth  = 40
+	defaultBoardHeight = 20
+)
+
+// Space taken up around the board: the border, the pod info panel and the
+// namespace legend beside it, and the header, kill log, status lines and
+// footer of a one-player game above and below it.
+const (
+	boardBorder  = 2
+	podInfoSpace = podInfoWidth + 11
+	legendSpace  = maxLegendName + 4
+	chromeHeight = 16
+)
+
+// chromeLines returns the height taken up above and below the board with
+// the given number of snakes. With more than one, the kill log gets a title
+// line per column and every snake a line of its own for active power-ups.
+func chromeLines(snakes int) int {
+	if snakes < 2 {
+		return chromeHeight
+	}
+	return chromeHeight + 1 + snakes - 1
+}
+
+// BoardSize fixes the board's dimensions in cells. The zero value sizes the
+// board from the terminal when a game starts.
+type BoardSize struct {
+	Width  int
+	Height int
+}
+
+// Auto reports whether the board is sized from the terminal.
+func (s BoardSize) Auto() bool {
+	return s.Width == 0 || s.Height == 0
+}
+
+func (s BoardSize) String() string {
+	if s.Auto() {
+		return "auto"
+	}
+	return fmt.Sprintf("%dx%d", s.Width, s.Height)
+}
+
+// boardSizes are the choices offered on the options screen.
+var boardSizes = []BoardSize{{}, {20, 10}, {40, 20}, {60, 25}, {80, 30}}
+
+// ParseBoardSize parses "auto" or a fixed size such as "60x25".
+func ParseBoardSize(s string) (BoardSize, error) {
+	if s == "" || s == "auto" {
+		return BoardSize{}, nil
+	}
+	var size BoardSize
+	if _, err := fmt.Sscanf(s, "%dx%d", &size.Width, &size.Height); err != nil || s != size.String() {
+		return BoardSize{}, fmt.Errorf("invalid board size %q (want auto or WIDTHxHEIGHT, e.g. 60x25)", s)
+	}
+	if size.Width < minBoardWidth || size.Width > maxBoardWidth || size.Height < minBoardHeight || size.Height > maxBoardHeight {
+		return BoardSize{}, fmt.Errorf("board size %s out of range (%dx%d to %dx%d)",
+			s, minBoardWidth, minBoardHeight, maxBoardWidth, maxBoardHeight)
+	}
+	return size, nil
+}
+
+// boardDimensions returns the board size in cells for a new game with the
+// given number of snakes in a terminal of the given size. Auto sizing
+// leaves room for the pod info panel, and the legend too when playing
+// across all namespaces.
+func boardDimensions(size BoardSize, mode CellMode, width, height, snakes int, allNamespaces bool) (int, int) {
+	if !size.Auto() {
+		return size.Width, size.Height
+	}
+	if width == 0 || height == 0 {
+		return defaultBoardWidth, defaultBoardHeight
+	}
+	side := podInfoSpace
+	if allNamespaces {
+		side += legendSpace
+	}
+	w, h := width-boardBorder-side, height-chromeLines(snakes)
+	switch mode {
+	case CellsWide:
+		w /= 2
+	case CellsHalf:
+		h *= 2
+	}
+	return min(max(w, minBoardWidth), maxBoardWidth), min(max(h, minBoardHeight), maxBoardHeight)
+}
+
+// needed returns the terminal size the current board takes up.
+func (m GameModel) needed() (int, int) {
+	w, h := m.options.cellMode().screenSize(m.game.Board.Width, m.game.Board.Height)
+	return w + boardBorder, h + chromeLines(len(m.game.Players))
+}
+
+// tooSmall reports whether the terminal cannot show the board. A terminal
+// whose size is not known yet is never too small.
+func (m GameModel) tooSmall() bool {
+	if m.width == 0 {
+		return false
+	}
+	w, h := m.needed()
+	return m.width < w || m.height < h
+}
+
+// viewTooSmall stands in for the game while the terminal is too small for
+// the board. The game is paused by the caller until it fits again.
+func (m GameModel) viewTooSmall() string {
+	theme := m.theme
+	w, h := m.needed()
+	lines := []string{
+		lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render("terminal too small"),
+		"",
+		lipgloss.NewStyle().Foreground(theme.Foreground).Render(fmt.Sprintf("need %dx%d, have %dx%d",
+			w, h, m.width, m.height)),
+		lipgloss.NewStyle().Foreground(theme.Dim).Render("enlarge the window to carry on, or [esc] for the menu"),
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Center, lines...))
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kristinb/snakeinak8/internal/game"
+)
+
+func TestAutoBoardFitsTheTerminal(t *testing.T) {
+	const width, height = 160, 50
+	tests := []struct {
+		name    string
+		players int
+		bot     game.Strategy
+	}{
+		{name: "one player", players: 1},
+		{name: "two players", players: 2},
+		{name: "player and bot", players: 1, bot: game.ShortestPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGameOptions()
+			options.Players, options.Bot = tt.players, tt.bot
+			m := NewGameModel(nil, "snakefood", DefaultTheme(), options, width, height, "")
+			if m.tooSmall() {
+				t.Fatal("expected an auto-sized board to fit its terminal")
+			}
+
+			// Fill every line around the board.
+			for i := 0; i < 20; i++ {
+				m.logKill(i%(len(m.game.Players)+1)-1, resultEvaporated, game.Pod{Name: "web"}, "web")
+			}
+			for _, p := range m.game.Players {
+				p.Effects = map[game.PowerUp]int{game.PowerInvincible: 10}
+			}
+			m.podStatus, m.itemStatus = "fetching pods", "spawned a service"
+
+			if h := lipgloss.Height(m.View()); h > height {
+				t.Fatalf("expected the view to fit in %d lines, got %d", height, h)
+			}
+			if _, h := m.needed(); h > height {
+				t.Fatalf("expected the board to need at most %d lines, got %d", height, h)
+			}
+		})
+	}
+}
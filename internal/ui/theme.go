@@ -1,22 +1,44 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"slices"
+	"strconv"
 
-// Theme defines the color palette and styles inspired by OpenClaw TUI.
-// Warm dark background with golden accents.
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme holds the colors, glyphs and styles the UI is drawn with. The
+// default is inspired by OpenClaw TUI: warm dark background with golden
+// accents.
 type Theme struct {
+	Name    string
+	Glyphs  Glyphs
+	palette Palette // what the colors were built from
+
 	// Colors
-	Background  lipgloss.Color
-	Foreground  lipgloss.Color
-	Accent      lipgloss.Color
-	AccentSoft  lipgloss.Color
-	Dim         lipgloss.Color
-	Border      lipgloss.Color
-	Error       lipgloss.Color
-	Success     lipgloss.Color
-	SnakeHead   lipgloss.Color
-	SnakeBody   lipgloss.Color
-	PodColor    lipgloss.Color
+	Background   lipgloss.Color
+	Foreground   lipgloss.Color
+	Accent       lipgloss.Color
+	AccentSoft   lipgloss.Color
+	Dim          lipgloss.Color
+	Border       lipgloss.Color
+	Error        lipgloss.Color
+	Success      lipgloss.Color
+	SnakeHead    lipgloss.Color
+	SnakeBody    lipgloss.Color
+	RivalHead    lipgloss.Color // second player's snake
+	RivalBody    lipgloss.Color
+	BotHead      lipgloss.Color // computer-controlled snake
+	BotBody      lipgloss.Color
+	PodColor     lipgloss.Color
+	WallColor    lipgloss.Color
+	HazardColor  lipgloss.Color
+	PowerUpColor lipgloss.Color
+
+	// NamespaceColors is cycled through to tell namespaces apart when
+	// playing across all namespaces.
+	NamespaceColors []lipgloss.Color
 
 	// Derived styles
 	HeaderStyle  lipgloss.Style
@@ -27,20 +49,154 @@ type Theme struct {
 	KillLogStyle lipgloss.Style
 }
 
+// Palette holds a theme's colors as hex (#RRGGBB) or ANSI color numbers
+// (0-255). It is what theme files set.
+type Palette struct {
+	Background string   `json:"background" toml:"background"`
+	Foreground string   `json:"foreground" toml:"foreground"`
+	Accent     string   `json:"accent" toml:"accent"`
+	AccentSoft string   `json:"accent-soft" toml:"accent-soft"`
+	Dim        string   `json:"dim" toml:"dim"`
+	Border     string   `json:"border" toml:"border"`
+	Error      string   `json:"error" toml:"error"`
+	Success    string   `json:"success" toml:"success"`
+	SnakeHead  string   `json:"snake-head" toml:"snake-head"`
+	SnakeBody  string   `json:"snake-body" toml:"snake-body"`
+	RivalHead  string   `json:"rival-head" toml:"rival-head"`
+	RivalBody  string   `json:"rival-body" toml:"rival-body"`
+	BotHead    string   `json:"bot-head" toml:"bot-head"`
+	BotBody    string   `json:"bot-body" toml:"bot-body"`
+	Pod        string   `json:"pod" toml:"pod"`
+	Wall       string   `json:"wall" toml:"wall"`
+	Hazard     string   `json:"hazard" toml:"hazard"`
+	PowerUp    string   `json:"power-up" toml:"power-up"`
+	Namespaces []string `json:"namespaces" toml:"namespaces"`
+}
+
+// colors returns pointers to every color in the palette, for checking and
+// converting them all alike.
+func (p *Palette) colors() []*string {
+	c := []*string{
+		&p.Background, &p.Foreground, &p.Accent, &p.AccentSoft, &p.Dim, &p.Border,
+		&p.Error, &p.Success, &p.SnakeHead, &p.SnakeBody, &p.RivalHead, &p.RivalBody,
+		&p.BotHead, &p.BotBody, &p.Pod, &p.Wall, &p.Hazard, &p.PowerUp,
+	}
+	for i := range p.Namespaces {
+		c = append(c, &p.Namespaces[i])
+	}
+	return c
+}
+
+// Glyphs are the characters the board is drawn with. Each must be a
+// single terminal column wide.
+type Glyphs struct {
+	SnakeHead   string `json:"snake-head" toml:"snake-head"`
+	SnakeBody   string `json:"snake-body" toml:"snake-body"`
+	RivalHead   string `json:"rival-head" toml:"rival-head"`
+	RivalBody   string `json:"rival-body" toml:"rival-body"`
+	BotHead     string `json:"bot-head" toml:"bot-head"`
+	BotBody     string `json:"bot-body" toml:"bot-body"`
+	Pod         string `json:"pod" toml:"pod"`
+	Pending     string `json:"pending" toml:"pending"`
+	CrashLoop   string `json:"crash-loop" toml:"crash-loop"`
+	Terminating string `json:"terminating" toml:"terminating"`
+	Decoy       string `json:"decoy" toml:"decoy"`
+	Wall        string `json:"wall" toml:"wall"`
+	Hazard      string `json:"hazard" toml:"hazard"`
+	ConfigMap   string `json:"config-map" toml:"config-map"`
+	Service     string `json:"service" toml:"service"`
+	Secret      string `json:"secret" toml:"secret"`
+	PVC         string `json:"pvc" toml:"pvc"`
+
+	// Fading is shown while an evaporated pod fades away. Empty animates
+	// the fade instead.
+	Fading string `json:"fading" toml:"fading"`
+}
+
+// DefaultGlyphs returns the classic ASCII glyphs.
+func DefaultGlyphs() Glyphs {
+	return Glyphs{
+		SnakeHead:   CellSnakeHead,
+		SnakeBody:   CellSnakeBody,
+		RivalHead:   CellSnakeHead,
+		RivalBody:   CellSnakeBody,
+		BotHead:     CellSnakeHead,
+		BotBody:     CellSnakeBody,
+		Pod:         CellPod,
+		Pending:     CellPending,
+		CrashLoop:   CellPod,
+		Terminating: CellPod,
+		Decoy:       CellDecoy,
+		Wall:        CellWall,
+		Hazard:      CellHazard,
+		ConfigMap:   CellConfigMap,
+		Service:     CellService,
+		Secret:      CellSecret,
+		PVC:         CellPVC,
+	}
+}
+
+// DistinctGlyphs returns glyphs that tell every game element apart
+// without color: each snake, each pod phase and the fading pods get a
+// character of their own.
+func DistinctGlyphs() Glyphs {
+	return DefaultGlyphs().distinct()
+}
+
+// distinct swaps the glyphs that only color tells apart for the distinct
+// ones and keeps the rest.
+func (g Glyphs) distinct() Glyphs {
+	g.RivalHead, g.RivalBody = "%", "="
+	g.BotHead, g.BotBody = "&", "+"
+	g.Pending = CellPending
+	g.CrashLoop = "~"
+	g.Terminating = "-"
+	g.Fading = "'"
+	return g
+}
+
+// all returns pointers to every glyph that must be set, for checking them
+// all alike.
+func (g *Glyphs) all() []*string {
+	return []*string{
+		&g.SnakeHead, &g.SnakeBody, &g.RivalHead, &g.RivalBody, &g.BotHead, &g.BotBody,
+		&g.Pod, &g.Pending, &g.CrashLoop, &g.Terminating, &g.Decoy, &g.Wall, &g.Hazard,
+		&g.ConfigMap, &g.Service, &g.Secret, &g.PVC,
+	}
+}
+
 // DefaultTheme returns the OpenClaw-inspired color scheme.
 func DefaultTheme() Theme {
+	return NewTheme("default", defaultPalette(), DefaultGlyphs())
+}
+
+// NewTheme builds a theme and its derived styles from a palette.
+func NewTheme(name string, p Palette, g Glyphs) Theme {
 	t := Theme{
-		Background: lipgloss.Color("#2B2F36"),
-		Foreground: lipgloss.Color("#E8E3D5"),
-		Accent:     lipgloss.Color("#F6C453"),
-		AccentSoft: lipgloss.Color("#F2A65A"),
-		Dim:        lipgloss.Color("#7B7F87"),
-		Border:     lipgloss.Color("#3C414B"),
-		Error:      lipgloss.Color("#F97066"),
-		Success:    lipgloss.Color("#7DD3A5"),
-		SnakeHead:  lipgloss.Color("#F6C453"),
-		SnakeBody:  lipgloss.Color("#F2A65A"),
-		PodColor:   lipgloss.Color("#7DD3A5"),
+		Name:         name,
+		Glyphs:       g,
+		palette:      p,
+		Background:   lipgloss.Color(p.Background),
+		Foreground:   lipgloss.Color(p.Foreground),
+		Accent:       lipgloss.Color(p.Accent),
+		AccentSoft:   lipgloss.Color(p.AccentSoft),
+		Dim:          lipgloss.Color(p.Dim),
+		Border:       lipgloss.Color(p.Border),
+		Error:        lipgloss.Color(p.Error),
+		Success:      lipgloss.Color(p.Success),
+		SnakeHead:    lipgloss.Color(p.SnakeHead),
+		SnakeBody:    lipgloss.Color(p.SnakeBody),
+		RivalHead:    lipgloss.Color(p.RivalHead),
+		RivalBody:    lipgloss.Color(p.RivalBody),
+		BotHead:      lipgloss.Color(p.BotHead),
+		BotBody:      lipgloss.Color(p.BotBody),
+		PodColor:     lipgloss.Color(p.Pod),
+		WallColor:    lipgloss.Color(p.Wall),
+		HazardColor:  lipgloss.Color(p.Hazard),
+		PowerUpColor: lipgloss.Color(p.PowerUp),
+	}
+	for _, c := range p.Namespaces {
+		t.NamespaceColors = append(t.NamespaceColors, lipgloss.Color(c))
 	}
 
 	t.HeaderStyle = lipgloss.NewStyle().
@@ -72,3 +228,30 @@ func DefaultTheme() Theme {
 
 	return t
 }
+
+// ForTerminal returns the theme as the terminal can show it. On a 16-color
+// terminal every color falls back to the nearest of the 16 ANSI colors,
+// which the terminal's own palette then renders. Other terminals get the
+// theme as it is: lipgloss brings it down to 256 colors where needed, and
+// without color it draws none.
+func (t Theme) ForTerminal() Theme {
+	if lipgloss.ColorProfile() != termenv.ANSI {
+		return t
+	}
+	p := t.palette
+	p.Namespaces = slices.Clone(p.Namespaces)
+	for _, c := range p.colors() {
+		if ansi, ok := termenv.ANSI.Color(*c).(termenv.ANSIColor); ok {
+			*c = strconv.Itoa(int(ansi))
+		}
+	}
+	return NewTheme(t.Name, p, t.Glyphs)
+}
+
+// NamespaceColor returns the color for the i-th namespace seen in a game.
+func (t Theme) NamespaceColor(i int) lipgloss.Color {
+	if len(t.NamespaceColors) == 0 {
+		return t.PodColor
+	}
+	return t.NamespaceColors[i%len(t.NamespaceColors)]
+}
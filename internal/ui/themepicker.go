@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// themesLoadedMsg carries the built-in themes and those found in ThemeDir.
+type themesLoadedMsg struct {
+	themes []Theme
+	errs   []error
+}
+
+func loadThemesCmd() tea.Cmd {
+	return func() tea.Msg {
+		themes, errs := AvailableThemes()
+		return themesLoadedMsg{themes: themes, errs: errs}
+	}
+}
+
+// updateThemes handles the Theme screen: enter switches to the theme under
+// the cursor and saves it in the settings file, r reloads the theme files.
+func (m MenuModel) updateThemes(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.themes)-1 {
+			m.cursor++
+		}
+	case "enter":
+		m.state = menuMain
+		if m.cursor < len(m.themes) {
+			m.theme = m.themes[m.cursor].ForTerminal()
+			m.options.Theme = m.theme.Name
+			m.cursor = 0
+			return m, saveConfigCmd(m.options)
+		}
+		m.cursor = 0
+	case "r":
+		return m, loadThemesCmd()
+	case "esc", "q":
+		m.state = menuMain
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+func (m MenuModel) viewThemes() string {
+	theme := m.theme
+
+	header := lipgloss.NewStyle().
+		Foreground(theme.AccentSoft).
+		Bold(true).
+		Render("  Theme")
+	where := lipgloss.NewStyle().
+		Foreground(theme.Dim).
+		Render("  theme files: " + ThemeDir() + "/*.yaml, *.toml")
+
+	var rows []string
+	for i, t := range m.themes {
+		name := lipgloss.NewStyle().Foreground(theme.Foreground).Render(padRight(t.Name, 16))
+		marker := "  "
+		if i == m.cursor {
+			name = lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render(padRight(t.Name, 16))
+			marker = lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render("> ")
+		}
+		if t.Name == m.theme.Name {
+			name += lipgloss.NewStyle().Foreground(theme.Dim).Render(" (current) ")
+		} else {
+			name += strings.Repeat(" ", 11)
+		}
+		rows = append(rows, marker+name+themeSwatch(t.ForTerminal()))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, lipgloss.NewStyle().Foreground(theme.Dim).Italic(true).Render("loading themes..."))
+	}
+
+	list := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	parts := []string{header, where, "", list}
+	for _, err := range m.themeErrs {
+		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Error).Render("  "+err))
+	}
+	parts = append(parts, "", lipgloss.NewStyle().Foreground(theme.Dim).Render("  [j/k] choose  [enter] use  [r] reload  [esc] back"))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+// themeSwatch previews a theme: a snake about to eat a pod, a decoy, a
+// wall and a power-up on the theme's own background.
+func themeSwatch(t Theme) string {
+	bg := lipgloss.NewStyle().Background(t.Background)
+	glyph := func(g string, c lipgloss.Color) string {
+		return bg.Foreground(c).Bold(true).Render(g)
+	}
+	return bg.Render(" ") +
+		glyph(t.Glyphs.SnakeBody, t.SnakeBody) + glyph(t.Glyphs.SnakeBody, t.SnakeBody) + glyph(t.Glyphs.SnakeHead, t.SnakeHead) +
+		bg.Render(" ") + glyph(t.Glyphs.Pod, t.PodColor) +
+		bg.Render(" ") + glyph(t.Glyphs.Decoy, t.HazardColor) +
+		bg.Render(" ") + glyph(t.Glyphs.Wall, t.WallColor) +
+		bg.Render(" ") + glyph(t.Glyphs.ConfigMap, t.PowerUpColor) +
+		bg.Render(" ") + glyph(t.Glyphs.RivalHead, t.RivalHead) + glyph(t.Glyphs.BotHead, t.BotHead) +
+		bg.Render(" ")
+}
+
+// padRight pads s with spaces to width columns.
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
@@ -0,0 +1,343 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigDir returns $XDG_CONFIG_HOME/snakeinak8, falling back to
+// ~/.config/snakeinak8.
+func ConfigDir() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "snakeinak8")
+}
+
+// ThemeDir returns the directory theme files are loaded from.
+func ThemeDir() string {
+	return filepath.Join(ConfigDir(), "themes")
+}
+
+func defaultPalette() Palette {
+	return Palette{
+		Background: "#2B2F36",
+		Foreground: "#E8E3D5",
+		Accent:     "#F6C453",
+		AccentSoft: "#F2A65A",
+		Dim:        "#7B7F87",
+		Border:     "#3C414B",
+		Error:      "#F97066",
+		Success:    "#7DD3A5",
+		SnakeHead:  "#F6C453",
+		SnakeBody:  "#F2A65A",
+		RivalHead:  "#8AB4F8",
+		RivalBody:  "#78D9EC",
+		BotHead:    "#F28B82",
+		BotBody:    "#FCAD70",
+		Pod:        "#7DD3A5",
+		Wall:       "#7B7F87",
+		Hazard:     "#F97066",
+		PowerUp:    "#C58AF9",
+		Namespaces: []string{
+			"#7DD3A5", "#8AB4F8", "#C58AF9", "#F28B82",
+			"#FDD663", "#78D9EC", "#FCAD70", "#E8E3D5",
+		},
+	}
+}
+
+// BuiltinThemes returns the themes that ship with the game.
+func BuiltinThemes() []Theme {
+	return []Theme{
+		DefaultTheme(),
+		NewTheme("light", Palette{
+			Background: "#FAFAF7",
+			Foreground: "#2E3440",
+			Accent:     "#B7791F",
+			AccentSoft: "#C05621",
+			Dim:        "#8A8F98",
+			Border:     "#D8DCE3",
+			Error:      "#C53030",
+			Success:    "#2F855A",
+			SnakeHead:  "#B7791F",
+			SnakeBody:  "#C05621",
+			RivalHead:  "#2B6CB0",
+			RivalBody:  "#3182CE",
+			BotHead:    "#C53030",
+			BotBody:    "#DD6B20",
+			Pod:        "#2F855A",
+			Wall:       "#8A8F98",
+			Hazard:     "#C53030",
+			PowerUp:    "#6B46C1",
+			Namespaces: []string{
+				"#2F855A", "#2B6CB0", "#6B46C1", "#C53030",
+				"#B7791F", "#319795", "#DD6B20", "#2E3440",
+			},
+		}, DefaultGlyphs()),
+		NewTheme("high-contrast", Palette{
+			Background: "#000000",
+			Foreground: "#FFFFFF",
+			Accent:     "#FFFF00",
+			AccentSoft: "#FFA500",
+			Dim:        "#C0C0C0",
+			Border:     "#FFFFFF",
+			Error:      "#FF0000",
+			Success:    "#00FF00",
+			SnakeHead:  "#FFFF00",
+			SnakeBody:  "#FFA500",
+			RivalHead:  "#00FFFF",
+			RivalBody:  "#00BFFF",
+			BotHead:    "#FF00FF",
+			BotBody:    "#FF69B4",
+			Pod:        "#00FF00",
+			Wall:       "#FFFFFF",
+			Hazard:     "#FF0000",
+			PowerUp:    "#8080FF",
+			Namespaces: []string{
+				"#00FF00", "#00FFFF", "#FFFF00", "#FF00FF",
+				"#FFFFFF", "#FFA500", "#8080FF", "#FF0000",
+			},
+		}, DefaultGlyphs()),
+		NewTheme("solarized", Palette{
+			Background: "#002B36",
+			Foreground: "#839496",
+			Accent:     "#B58900",
+			AccentSoft: "#CB4B16",
+			Dim:        "#586E75",
+			Border:     "#073642",
+			Error:      "#DC322F",
+			Success:    "#859900",
+			SnakeHead:  "#B58900",
+			SnakeBody:  "#CB4B16",
+			RivalHead:  "#268BD2",
+			RivalBody:  "#2AA198",
+			BotHead:    "#D33682",
+			BotBody:    "#6C71C4",
+			Pod:        "#859900",
+			Wall:       "#586E75",
+			Hazard:     "#DC322F",
+			PowerUp:    "#6C71C4",
+			Namespaces: []string{
+				"#859900", "#268BD2", "#6C71C4", "#D33682",
+				"#B58900", "#2AA198", "#CB4B16", "#93A1A1",
+			},
+		}, DefaultGlyphs()),
+		NewTheme("monochrome", Palette{
+			Background: "#000000",
+			Foreground: "#E0E0E0",
+			Accent:     "#FFFFFF",
+			AccentSoft: "#C0C0C0",
+			Dim:        "#808080",
+			Border:     "#606060",
+			Error:      "#FFFFFF",
+			Success:    "#E0E0E0",
+			SnakeHead:  "#FFFFFF",
+			SnakeBody:  "#C0C0C0",
+			RivalHead:  "#FFFFFF",
+			RivalBody:  "#C0C0C0",
+			BotHead:    "#FFFFFF",
+			BotBody:    "#C0C0C0",
+			Pod:        "#FFFFFF",
+			Wall:       "#808080",
+			Hazard:     "#A0A0A0",
+			PowerUp:    "#E0E0E0",
+			Namespaces: []string{"#E0E0E0"},
+		}, DistinctGlyphs()),
+		// Okabe-Ito colors, told apart with red-green color blindness.
+		NewTheme("colorblind", Palette{
+			Background: "#2B2F36",
+			Foreground: "#E8E3D5",
+			Accent:     "#E69F00",
+			AccentSoft: "#F0E442",
+			Dim:        "#7B7F87",
+			Border:     "#3C414B",
+			Error:      "#D55E00",
+			Success:    "#009E73",
+			SnakeHead:  "#F0E442",
+			SnakeBody:  "#E69F00",
+			RivalHead:  "#56B4E9",
+			RivalBody:  "#0072B2",
+			BotHead:    "#CC79A7",
+			BotBody:    "#CC79A7",
+			Pod:        "#009E73",
+			Wall:       "#7B7F87",
+			Hazard:     "#D55E00",
+			PowerUp:    "#FFFFFF",
+			Namespaces: []string{
+				"#009E73", "#56B4E9", "#E69F00", "#F0E442",
+				"#0072B2", "#D55E00", "#CC79A7", "#E8E3D5",
+			},
+		}, DistinctGlyphs()),
+	}
+}
+
+// themeFile is the on-disk form of a theme, in YAML or TOML. Anything left
+// out is taken from the base theme (the default unless base names another
+// built-in).
+type themeFile struct {
+	Name   string  `json:"name" toml:"name"`
+	Base   string  `json:"base" toml:"base"`
+	Colors Palette `json:"colors" toml:"colors"`
+	Glyphs Glyphs  `json:"glyphs" toml:"glyphs"`
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// validColor accepts #RRGGBB and ANSI color numbers.
+func validColor(c string) bool {
+	if hexColor.MatchString(c) {
+		return true
+	}
+	n, err := strconv.Atoi(c)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// ParseTheme reads a theme from YAML or TOML, telling them apart by the
+// file extension in path. The name defaults to the file name.
+func ParseTheme(path string, data []byte) (Theme, error) {
+	f := themeFile{Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+
+	// Decode once to find the base, then again on top of it.
+	decode := func(f *themeFile) error {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".toml":
+			md, err := toml.NewDecoder(bytes.NewReader(data)).Decode(f)
+			if err != nil {
+				return err
+			}
+			if extra := md.Undecoded(); len(extra) > 0 {
+				return fmt.Errorf("unknown key %s", extra[0])
+			}
+			return nil
+		case ".yaml", ".yml":
+			return yaml.UnmarshalStrict(data, f)
+		}
+		return fmt.Errorf("unknown theme format %q (want .yaml, .yml or .toml)", filepath.Ext(path))
+	}
+	if err := decode(&f); err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", path, err)
+	}
+	base := DefaultTheme()
+	if f.Base != "" {
+		var ok bool
+		if base, ok = findTheme(BuiltinThemes(), f.Base); !ok {
+			return Theme{}, fmt.Errorf("theme %s: unknown base theme %q", path, f.Base)
+		}
+	}
+	f.Colors, f.Glyphs = base.palette, base.Glyphs
+	f.Colors.Namespaces = nil
+	if err := decode(&f); err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", path, err)
+	}
+	if f.Colors.Namespaces == nil {
+		f.Colors.Namespaces = base.palette.Namespaces
+	}
+
+	for _, c := range f.Colors.colors() {
+		if !validColor(*c) {
+			return Theme{}, fmt.Errorf("theme %s: invalid color %q (want #RRGGBB or 0-255)", path, *c)
+		}
+	}
+	glyphs := f.Glyphs.all()
+	if f.Glyphs.Fading != "" {
+		glyphs = append(glyphs, &f.Glyphs.Fading)
+	}
+	for _, g := range glyphs {
+		if lipgloss.Width(*g) != 1 {
+			return Theme{}, fmt.Errorf("theme %s: glyph %q must be one column wide", path, *g)
+		}
+	}
+	return NewTheme(f.Name, f.Colors, f.Glyphs), nil
+}
+
+// LoadThemes reads every theme file in dir. A missing directory holds no
+// themes; files that fail to parse are reported and skipped.
+func LoadThemes(dir string) ([]Theme, []error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to read themes: %w", err)}
+	}
+
+	var themes []Theme
+	var errs []error
+	for _, file := range files {
+		switch strings.ToLower(filepath.Ext(file.Name())) {
+		case ".yaml", ".yml", ".toml":
+		default:
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		t, err := ParseTheme(path, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		themes = append(themes, t)
+	}
+	return themes, errs
+}
+
+// AvailableThemes returns the built-in themes followed by those in
+// ThemeDir. A file theme named like a built-in replaces it.
+func AvailableThemes() ([]Theme, []error) {
+	themes := BuiltinThemes()
+	loaded, errs := LoadThemes(ThemeDir())
+	for _, t := range loaded {
+		replaced := false
+		for i := range themes {
+			if themes[i].Name == t.Name {
+				themes[i], replaced = t, true
+			}
+		}
+		if !replaced {
+			themes = append(themes, t)
+		}
+	}
+	return themes, errs
+}
+
+// LoadTheme returns the available theme with the given name, or the
+// default theme for an empty name.
+func LoadTheme(name string) (Theme, error) {
+	themes, _ := AvailableThemes()
+	if name == "" {
+		return themes[0], nil
+	}
+	if t, ok := findTheme(themes, name); ok {
+		return t, nil
+	}
+	var names []string
+	for _, t := range themes {
+		names = append(names, t.Name)
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (want %s)", name, strings.Join(names, ", "))
+}
+
+func findTheme(themes []Theme, name string) (Theme, bool) {
+	for _, t := range themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestParseTheme(t *testing.T) {
+	solarized, _ := findTheme(BuiltinThemes(), "solarized")
+	monochrome, _ := findTheme(BuiltinThemes(), "monochrome")
+
+	tests := []struct {
+		name    string
+		path    string
+		data    string
+		wantErr string
+		check   func(t *testing.T, th Theme)
+	}{
+		{
+			name: "yaml merges over its base",
+			path: "mine.yaml",
+			data: "base: solarized\ncolors:\n  accent: \"#FF0000\"\nglyphs:\n  pod: \"o\"\n",
+			check: func(t *testing.T, th Theme) {
+				if th.Name != "mine" {
+					t.Errorf("expected the file name as theme name, got %q", th.Name)
+				}
+				if th.palette.Accent != "#FF0000" {
+					t.Errorf("expected the accent from the file, got %q", th.palette.Accent)
+				}
+				if th.palette.Background != solarized.palette.Background {
+					t.Errorf("expected the background from solarized, got %q", th.palette.Background)
+				}
+				if len(th.palette.Namespaces) != len(solarized.palette.Namespaces) {
+					t.Errorf("expected the namespace colors from solarized, got %v", th.palette.Namespaces)
+				}
+				if th.Glyphs.Pod != "o" || th.Glyphs.Wall != solarized.Glyphs.Wall {
+					t.Errorf("expected the pod glyph from the file and the rest from solarized, got %+v", th.Glyphs)
+				}
+			},
+		},
+		{
+			name: "toml merges over its base",
+			path: "mine.toml",
+			data: "name = \"night\"\nbase = \"monochrome\"\n[colors]\npod = \"42\"\nnamespaces = [\"#111111\", \"#222222\"]\n",
+			check: func(t *testing.T, th Theme) {
+				if th.Name != "night" {
+					t.Errorf("expected the name from the file, got %q", th.Name)
+				}
+				if th.palette.Pod != "42" || th.palette.Wall != monochrome.palette.Wall {
+					t.Errorf("expected the pod color from the file and the rest from monochrome, got %+v", th.palette)
+				}
+				if len(th.palette.Namespaces) != 2 {
+					t.Errorf("expected the file's namespace colors to replace the base's, got %v", th.palette.Namespaces)
+				}
+				if th.Glyphs != monochrome.Glyphs {
+					t.Errorf("expected monochrome's glyphs, got %+v", th.Glyphs)
+				}
+			},
+		},
+		{
+			name: "defaults to the default theme",
+			path: "plain.yml",
+			data: "colors:\n  wall: \"#123456\"\n",
+			check: func(t *testing.T, th Theme) {
+				if th.palette.Background != defaultPalette().Background {
+					t.Errorf("expected the default background, got %q", th.palette.Background)
+				}
+			},
+		},
+		{name: "unknown yaml key", path: "bad.yaml", data: "colours:\n  accent: \"#FF0000\"\n", wantErr: "unknown field"},
+		{name: "unknown toml key", path: "bad.toml", data: "[colors]\nsparkle = \"#FF0000\"\n", wantErr: "unknown key"},
+		{name: "unknown base", path: "bad.yaml", data: "base: neon\n", wantErr: "unknown base theme"},
+		{name: "named color", path: "bad.yaml", data: "colors:\n  accent: red\n", wantErr: "invalid color"},
+		{name: "ansi color out of range", path: "bad.toml", data: "[colors]\naccent = \"256\"\n", wantErr: "invalid color"},
+		{name: "bad namespace color", path: "bad.yaml", data: "colors:\n  namespaces: [\"#12345\"]\n", wantErr: "invalid color"},
+		{name: "wide glyph", path: "bad.yaml", data: "glyphs:\n  pod: \"蛇\"\n", wantErr: "one column wide"},
+		{name: "two glyphs", path: "bad.toml", data: "[glyphs]\nwall = \"##\"\n", wantErr: "one column wide"},
+		{name: "empty glyph", path: "bad.yaml", data: "glyphs:\n  wall: \"\"\n", wantErr: "one column wide"},
+		{name: "unknown format", path: "theme.json", data: "{}", wantErr: "unknown theme format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th, err := ParseTheme(tt.path, []byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, th)
+		})
+	}
+}
+
+func TestLoadThemes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"good.yaml":   "colors:\n  accent: \"#FF0000\"\n",
+		"broken.toml": "[colors]\naccent = \"red\"\n",
+		"README.md":   "not a theme",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	themes, errs := LoadThemes(dir)
+	if len(themes) != 1 || themes[0].Name != "good" {
+		t.Fatalf("expected only the good theme, got %d themes", len(themes))
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "broken.toml") {
+		t.Fatalf("expected broken.toml to be reported, got %v", errs)
+	}
+
+	if themes, errs := LoadThemes(filepath.Join(dir, "missing")); themes != nil || errs != nil {
+		t.Fatalf("expected a missing directory to hold no themes, got %v and %v", themes, errs)
+	}
+}
+
+func TestForTerminal(t *testing.T) {
+	defer lipgloss.SetColorProfile(lipgloss.ColorProfile())
+	solarized, _ := findTheme(BuiltinThemes(), "solarized")
+
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	if th := solarized.ForTerminal(); th.palette.Accent != solarized.palette.Accent {
+		t.Errorf("expected a 256-color terminal to keep the theme's colors, got accent %q", th.palette.Accent)
+	}
+
+	lipgloss.SetColorProfile(termenv.ANSI)
+	th := solarized.ForTerminal()
+	if n, err := strconv.Atoi(th.palette.Accent); err != nil || n > 15 {
+		t.Errorf("expected a 16-color terminal to get an ANSI accent, got %q", th.palette.Accent)
+	}
+	if solarized.palette.Accent == th.palette.Accent {
+		t.Error("expected the original theme to be left alone")
+	}
+}
+
+func TestBoardThemeKeepsTheThemesGlyphs(t *testing.T) {
+	theme := DefaultTheme()
+	theme.Glyphs.Pod, theme.Glyphs.Wall = "o", "X"
+	options := DefaultGameOptions()
+	options.DistinctGlyphs = true
+	m := GameModel{theme: theme, options: options}
+
+	g := m.boardTheme().Glyphs
+	if g.Pod != "o" || g.Wall != "X" || g.SnakeHead != theme.Glyphs.SnakeHead {
+		t.Errorf("expected the theme's own glyphs to stay, got %+v", g)
+	}
+	if g.RivalHead == g.SnakeHead || g.BotHead == g.SnakeHead || g.CrashLoop == g.Pod || g.Terminating == g.Pod {
+		t.Errorf("expected the glyphs told apart by color to get their own, got %+v", g)
+	}
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kristinb/snakeinak8/internal/game"
+	"github.com/kristinb/snakeinak8/internal/k8s"
+)
+
+// rewatchDelay is how long to wait before re-opening a closed pod watch.
+const rewatchDelay = 2 * time.Second
+
+// watchStartedMsg carries the pods listed when a pod watch opened and the
+// event stream that follows.
+type watchStartedMsg struct {
+	pods   []k8s.PodEvent
+	events <-chan k8s.PodEvent
+	err    error
+}
+
+// podEventMsg signals a pod was deleted or changed phase in the cluster.
+type podEventMsg k8s.PodEvent
+
+// watchClosedMsg signals the pod watch ended and should be re-opened.
+type watchClosedMsg struct{}
+
+// watchPodsCmd opens a pod watch that lives until ctx is cancelled.
+func watchPodsCmd(ctx context.Context, client *k8s.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+		pods, events, err := client.WatchPods(ctx)
+		return watchStartedMsg{pods: pods, events: events, err: err}
+	}
+}
+
+// nextPodEventCmd waits for the next event on the watch stream.
+func nextPodEventCmd(events <-chan k8s.PodEvent) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return watchClosedMsg{}
+		}
+		return podEventMsg(ev)
+	}
+}
+
+// rewatchCmd re-opens the pod watch after a short delay, unless the game
+// has been left in the meantime.
+func rewatchCmd(ctx context.Context, client *k8s.Client) tea.Cmd {
+	return tea.Tick(rewatchDelay, func(time.Time) tea.Msg {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return watchPodsCmd(ctx, client)()
+	})
+}
+
+// applyPodEvent follows a pod on the board through a phase change, or lets
+// it evaporate when it was deleted or stopped being shown: no points, no
+// kill.
+func (m *GameModel) applyPodEvent(ev k8s.PodEvent) {
+	phase, shown := game.ParsePodPhase(ev.Phase)
+	if !ev.Deleted && shown && (m.options.AllPhases || phase == game.PhaseRunning) {
+		m.game.SetPodPhase(ev.Name, ev.Namespace, phase)
+		return
+	}
+	if pod, ok := m.game.RemovePod(ev.Name, ev.Namespace); ok {
+		reason := "deleted"
+		if !ev.Deleted {
+			reason = strings.ToLower(ev.Phase)
+		}
+		m.logKill(-1, resultEvaporated, pod, "evaporated: "+pod.Namespace+"/"+pod.Name+" ("+reason+")")
+		delete(m.knownPods, pod.Name)
+	}
+}
+
+// resyncPods catches up with the pods listed when the watch (re)opened.
+// Pods on the board that are missing from the list were deleted while
+// nobody was watching.
+func (m *GameModel) resyncPods(listed []k8s.PodEvent) {
+	present := make(map[string]bool, len(listed))
+	for _, ev := range listed {
+		present[ev.Namespace+"/"+ev.Name] = true
+		m.applyPodEvent(ev)
+	}
+	for _, pod := range slices.Clone(m.game.Pods) {
+		if !present[pod.Namespace+"/"+pod.Name] {
+			m.applyPodEvent(k8s.PodEvent{Name: pod.Name, Namespace: pod.Namespace, Deleted: true})
+		}
+	}
+}
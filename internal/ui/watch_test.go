@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/kristinb/snakeinak8/internal/game"
+	"github.com/kristinb/snakeinak8/internal/k8s"
+)
+
+func TestResyncDropsPodsDeletedWhileUnwatched(t *testing.T) {
+	g := game.New(20, 10)
+	for _, name := range []string{"kept", "gone", "stopping"} {
+		if !g.AddPod(game.Pod{Name: name, Namespace: "snakefood", Phase: game.PhaseRunning}) {
+			t.Fatalf("failed to place %s", name)
+		}
+	}
+	m := &GameModel{game: g, knownPods: map[string]bool{"kept": true, "gone": true, "stopping": true}}
+
+	m.resyncPods([]k8s.PodEvent{
+		{Name: "kept", Namespace: "snakefood", Phase: k8s.PhaseRunning},
+		{Name: "stopping", Namespace: "snakefood", Phase: k8s.PhaseTerminating},
+		{Name: "other", Namespace: "snakefood", Phase: k8s.PhaseRunning},
+	})
+	if len(g.Pods) != 1 || g.Pods[0].Name != "kept" {
+		t.Fatalf("expected only the listed running pod to stay, got %+v", g.Pods)
+	}
+	if m.knownPods["gone"] || m.knownPods["stopping"] || !m.knownPods["kept"] {
+		t.Fatalf("expected the dropped pods to be forgotten, got %v", m.knownPods)
+	}
+	if len(m.killLog) != 2 || m.killLog[0].Result != resultEvaporated {
+		t.Fatalf("expected two evaporated pods in the kill log, got %+v", m.killLog)
+	}
+}
@@ -4,19 +4,96 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kristinb/snakeinak8/internal/game"
 	"github.com/kristinb/snakeinak8/internal/k8s"
 	"github.com/kristinb/snakeinak8/internal/ui"
+	"github.com/muesli/termenv"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		os.Exit(runCommand(os.Args[2:]))
+	}
+
+	options := ui.DefaultGameOptions()
+	if err := ui.LoadConfig(ui.ConfigPath(), &options); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
+
 	kubeconfigFlag := flag.String("kubeconfig", "", "path to kubeconfig file (defaults to KUBECONFIG env or ~/.kube/config)")
+	flag.Func("mode", "game mode: classic, time-attack (60s), survival (pods harden into walls) or zen (no death, wrap-around)", func(s string) (err error) {
+		options.Mode, err = game.ParseMode(s)
+		return err
+	})
+	flag.Func("layout", "board layout: random, nodes (one room per cluster node) or namespaces (one zone per namespace)", func(s string) (err error) {
+		options.Layout, err = game.ParseLayout(s)
+		return err
+	})
+	flag.Func("difficulty", "difficulty preset: easy, normal or hard (default normal)", func(s string) (err error) {
+		options.Difficulty, err = game.ParseDifficulty(s)
+		return err
+	})
+	flag.BoolVar(&options.PowerUps, "powerups", false, "spawn ConfigMaps, Services, Secrets and PVCs as power-ups (read-only)")
+	flag.Func("decoys", "protected pods ("+k8s.ProtectedSelector+") as decoys: off, end (game over) or points (lose points)", func(s string) (err error) {
+		options.Decoys, err = game.ParseDecoyRule(s)
+		return err
+	})
+	flag.Func("pod-movement", "pod movement: off, random or flee", func(s string) (err error) {
+		options.Movement, err = game.ParsePodMovement(s)
+		return err
+	})
+	flag.BoolVar(&options.AllPhases, "all-phases", false, "also show Pending, CrashLoopBackOff and Terminating pods")
+	flag.Func("board-size", "board size in cells: auto (fit the terminal) or WIDTHxHEIGHT, e.g. 60x25", func(s string) (err error) {
+		options.BoardSize, err = ui.ParseBoardSize(s)
+		return err
+	})
+	flag.DurationVar(&options.TickRate, "tick-rate", options.TickRate, "starting time between moves, e.g. 120ms (default the difficulty's)")
+	flag.IntVar(&options.MaxPods, "max-pods", options.MaxPods, "pods on the board at once (default the difficulty's)")
+	flag.DurationVar(&options.FetchTimeout, "fetch-timeout", options.FetchTimeout, "timeout for fetching and killing a pod or power-up")
+	flag.DurationVar(&options.ClusterTimeout, "cluster-timeout", options.ClusterTimeout, "timeout for listing namespaces and nodes")
+	flag.Func("cells", "how board cells are drawn: narrow (one column), wide (two columns, square) or half (half blocks, square, no glyphs; wide with --distinct-glyphs or NO_COLOR)", func(s string) (err error) {
+		options.Cells, err = ui.ParseCellMode(s)
+		return err
+	})
+	flag.Func("theme", "color theme: default, light, high-contrast, solarized, monochrome, colorblind or one from "+ui.ThemeDir(), func(s string) error {
+		if _, err := ui.LoadTheme(s); err != nil {
+			return err
+		}
+		options.Theme = s
+		return nil
+	})
+	flag.BoolVar(&options.DistinctGlyphs, "distinct-glyphs", false, "draw every game element with its own character so the board reads without color (on when NO_COLOR is set)")
+	flag.Func("players", fmt.Sprintf("local players on one keyboard, 1 to %d (P1: wasd, P2: arrows)", ui.MaxLocalPlayers), func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > ui.MaxLocalPlayers {
+			return fmt.Errorf("players must be between 1 and %d", ui.MaxLocalPlayers)
+		}
+		options.Players = n
+		return nil
+	})
+	flag.BoolVar(&options.Autopilot, "autoplay", false, "skip the menu and let a pathfinding bot play, e.g. on a wall display")
+	flag.BoolVar(&options.Attract, "attract", false, "after a minute idle in the main menu, play on autopilot until a key is pressed (deletes pods like autopilot)")
+	flag.IntVar(&options.KillRate, "kill-rate", options.KillRate, "most pods autopilot may kill per minute (0 for no cap)")
+	flag.StringVar(&options.Leaderboard, "leaderboard", "", "share high scores through a ConfigMap in this namespace (needs get/create/update on configmaps there)")
+	flag.Func("ai", "computer-controlled opponent: off, easy, normal or hard, or a strategy by name: greedy (easy), bfs (normal) or hamiltonian (hard)", func(s string) (err error) {
+		options.Bot, err = game.ParseOpponent(s)
+		return err
+	})
 	flag.Parse()
+	applyNoColor(&options)
+	if err := options.Validate(0, 0, true); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
 
 	kubeconfigPath := k8s.ResolveKubeconfig(*kubeconfigFlag)
 
-	m := ui.NewMenuModel(kubeconfigPath)
+	m := ui.NewMenuModel(kubeconfigPath, options)
 	p := tea.NewProgram(m, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
@@ -27,3 +104,13 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// applyNoColor turns color off for the whole process when NO_COLOR is set.
+// Without color only the glyphs tell things apart, so they are made
+// distinct.
+func applyNoColor(options *ui.GameOptions) {
+	if ui.NoColor() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		options.DistinctGlyphs = true
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kristinb/snakeinak8/internal/game"
+	"github.com/kristinb/snakeinak8/internal/headless"
+	"github.com/kristinb/snakeinak8/internal/k8s"
+	"github.com/kristinb/snakeinak8/internal/ui"
+)
+
+// runCommand implements `snakeinak8 run`: the game plays itself on
+// autopilot, in the TUI or, with --headless, logging JSON to stdout until
+// --duration is up. Returns the process exit code.
+func runCommand(args []string) int {
+	// The settings file applies here too; flags override it.
+	options := ui.DefaultGameOptions()
+	if err := ui.LoadConfig(ui.ConfigPath(), &options); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	kubeconfigFlag := fs.String("kubeconfig", "", "path to kubeconfig file (defaults to KUBECONFIG env, ~/.kube/config, or the pod's service account)")
+	namespace := fs.String("namespace", "", "namespace to hunt in (default all namespaces)")
+	isHeadless := fs.Bool("headless", false, "no TUI: log each kill as JSON to stdout and print a summary at the end")
+	duration := fs.Duration("duration", 0, "how long to run with --headless, e.g. 30m (default until interrupted)")
+	fs.IntVar(&options.KillRate, "kill-rate", options.KillRate, "most pods killed per minute (0 for no cap)")
+	fs.BoolVar(&options.AllPhases, "all-phases", false, "also hunt Pending, CrashLoopBackOff and Terminating pods")
+	strategy := game.ShortestPath
+	fs.Func("strategy", "bot strategy: greedy, bfs or hamiltonian (default bfs)", func(s string) (err error) {
+		strategy, err = game.ParseStrategy(s)
+		return err
+	})
+	fs.Func("difficulty", "difficulty preset: easy, normal or hard (default normal)", func(s string) (err error) {
+		options.Difficulty, err = game.ParseDifficulty(s)
+		return err
+	})
+	fs.Func("board-size", "board size in cells: auto or WIDTHxHEIGHT, e.g. 60x25 (auto is 40x20 with --headless)", func(s string) (err error) {
+		options.BoardSize, err = ui.ParseBoardSize(s)
+		return err
+	})
+	fs.DurationVar(&options.TickRate, "tick-rate", options.TickRate, "starting time between moves, e.g. 120ms (default the difficulty's)")
+	fs.IntVar(&options.MaxPods, "max-pods", options.MaxPods, "pods on the board at once (default the difficulty's)")
+	fs.DurationVar(&options.FetchTimeout, "fetch-timeout", options.FetchTimeout, "timeout for fetching and killing a pod")
+	_ = fs.Parse(args)
+	if *duration != 0 && !*isHeadless {
+		fmt.Fprintln(os.Stderr, "error: --duration needs --headless")
+		return 2
+	}
+	if err := options.Validate(0, 0, *namespace == ""); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+
+	kubeconfigPath := k8s.ResolveKubeconfig(*kubeconfigFlag)
+
+	if !*isHeadless {
+		applyNoColor(&options)
+		options.Autopilot = true
+		options.AutopilotStrategy = strategy
+		menu := ui.NewMenuModel(kubeconfigPath, options).WithNamespace(*namespace)
+		if _, err := tea.NewProgram(menu, tea.WithAltScreen()).Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	log := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	client, err := k8s.NewClient(kubeconfigPath, *namespace)
+	if err != nil {
+		log.Error("connect_failed", "error", err.Error())
+		return 1
+	}
+	client.SetAllPhases(options.AllPhases)
+
+	// A CronJob stops us with SIGTERM; finish with a summary either way.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	headless.Run(ctx, client, headless.Config{
+		Duration:     *duration,
+		Strategy:     strategy,
+		Difficulty:   options.Difficulty.Tuned(options.TickRate, options.MaxPods),
+		KillRate:     options.KillRate,
+		Width:        options.BoardSize.Width,
+		Height:       options.BoardSize.Height,
+		FetchTimeout: options.FetchTimeout,
+	}, log)
+	return 0
+}